@@ -0,0 +1,135 @@
+// Package apperror 定义返回给客户端的应用错误：稳定的错误码、HTTP 状态码、安全的提示信息和字段级详情。
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"gorm.io/gorm"
+)
+
+// 错误码是对外的稳定约定，客户端应根据 code 而不是 message 处理错误。
+const (
+	CodeInvalidInput     = "invalid_input"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeInternal         = "internal_error"
+	CodeUnavailable      = "service_unavailable"
+	CodeRateLimited      = "rate_limited"
+)
+
+// 各类错误的哨兵值，可用 errors.Is 按错误码判断，例如 errors.Is(err, apperror.ErrNotFound)。
+var (
+	ErrInvalidInput = &Error{Code: CodeInvalidInput, Status: http.StatusBadRequest}
+	ErrUnauthorized = &Error{Code: CodeUnauthorized, Status: http.StatusUnauthorized}
+	ErrForbidden    = &Error{Code: CodeForbidden, Status: http.StatusForbidden}
+	ErrNotFound     = &Error{Code: CodeNotFound, Status: http.StatusNotFound}
+	ErrConflict     = &Error{Code: CodeConflict, Status: http.StatusConflict}
+	ErrInternal     = &Error{Code: CodeInternal, Status: http.StatusInternalServerError}
+)
+
+// Error 是应用错误。Message 会原样返回给客户端，Err 是内部原因，只记录日志不对外暴露。
+// RetryAfter 大于 0 时响应会带上 Retry-After 头。
+type Error struct {
+	Code       string
+	Status     int
+	Message    string
+	Details    []FieldError
+	RetryAfter time.Duration
+	Err        error
+}
+
+// FieldError 描述单个请求字段的校验失败。
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return e.Code + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is 按错误码比较，使哨兵值可以匹配任意同类错误。
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func New(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+func BadRequest(message string) *Error {
+	return New(CodeInvalidInput, http.StatusBadRequest, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, http.StatusUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, http.StatusForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return New(CodeNotFound, http.StatusNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(CodeConflict, http.StatusConflict, message)
+}
+
+// TooManyRequests 表示请求过于频繁，retryAfter 为客户端需要等待的时间。
+func TooManyRequests(message string, retryAfter time.Duration) *Error {
+	return &Error{Code: CodeRateLimited, Status: http.StatusTooManyRequests, Message: message, RetryAfter: retryAfter}
+}
+
+// Internal 包装内部错误，客户端只能看到通用提示。
+func Internal(err error) *Error {
+	return &Error{Code: CodeInternal, Status: http.StatusInternalServerError, Message: "Internal server error", Err: err}
+}
+
+// From 把任意错误转换为应用错误：GORM 和 repository 的记录不存在、唯一键冲突分别映射为 404 和 409，
+// 会话已轮换映射为 401，其他未知错误一律视为内部错误。
+func From(err error) *Error {
+	var appErr *Error
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, repository.ErrNotFound):
+		return &Error{Code: CodeNotFound, Status: http.StatusNotFound, Message: "Resource not found", Err: err}
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.Is(err, repository.ErrDuplicate):
+		return &Error{Code: CodeConflict, Status: http.StatusConflict, Message: "Resource already exists", Err: err}
+	case errors.Is(err, repository.ErrAlreadyRotated):
+		return &Error{Code: CodeUnauthorized, Status: http.StatusUnauthorized, Message: "Session is no longer valid", Err: err}
+	default:
+		return Internal(err)
+	}
+}
+
+// Wrap 与 From 相同，但内部错误使用 message 作为对外提示，例如 "Failed to fetch posts"。
+func Wrap(err error, message string) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	appErr = From(err)
+	if appErr.Code == CodeInternal {
+		appErr.Message = message
+	}
+	return appErr
+}
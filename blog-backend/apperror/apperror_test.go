@@ -0,0 +1,42 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"gorm.io/gorm"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   string
+		status int
+	}{
+		{gorm.ErrRecordNotFound, CodeNotFound, http.StatusNotFound},
+		{gorm.ErrDuplicatedKey, CodeConflict, http.StatusConflict},
+		{repository.ErrNotFound, CodeNotFound, http.StatusNotFound},
+		{fmt.Errorf("find post: %w", repository.ErrNotFound), CodeNotFound, http.StatusNotFound},
+		{repository.ErrDuplicate, CodeConflict, http.StatusConflict},
+		{repository.ErrAlreadyRotated, CodeUnauthorized, http.StatusUnauthorized},
+		{Forbidden("no"), CodeForbidden, http.StatusForbidden},
+		{errors.New("boom"), CodeInternal, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		got := From(tt.err)
+		if got.Code != tt.code || got.Status != tt.status {
+			t.Errorf("From(%v) = %s %d, want %s %d", tt.err, got.Code, got.Status, tt.code, tt.status)
+		}
+	}
+
+	// Wrap 只替换内部错误的提示，已映射的错误保留原提示
+	if got := Wrap(repository.ErrNotFound, "Failed to fetch post"); got.Message != "Resource not found" {
+		t.Errorf("Wrap(ErrNotFound).Message = %q", got.Message)
+	}
+	if got := Wrap(errors.New("boom"), "Failed to fetch post"); got.Message != "Failed to fetch post" {
+		t.Errorf("Wrap(internal).Message = %q", got.Message)
+	}
+}
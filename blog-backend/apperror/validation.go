@@ -0,0 +1,99 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// UseJSONFieldNames 让 gin 的校验器在错误中使用 json 标签名，使 details 中的字段名与请求体一致。
+func UseJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+}
+
+// Bind 转换 ShouldBind 系列方法返回的错误：校验失败时附带字段详情，其他情况（JSON 格式错误、
+// 类型不匹配、空请求体）统一为 invalid_input。
+func Bind(err error) *Error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return &Error{Code: CodeInvalidInput, Status: http.StatusBadRequest, Message: "Invalid request body", Err: err}
+	}
+
+	details := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		details = append(details, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+	return &Error{
+		Code:    CodeValidationFailed,
+		Status:  http.StatusBadRequest,
+		Message: "Validation failed",
+		Details: details,
+		Err:     err,
+	}
+}
+
+// Invalid 构造单个字段的校验错误，用于请求绑定之后在业务层发现的字段问题。
+func Invalid(field, rule, message string) *Error {
+	return &Error{
+		Code:    CodeValidationFailed,
+		Status:  http.StatusBadRequest,
+		Message: "Validation failed",
+		Details: []FieldError{{Field: field, Rule: rule, Message: message}},
+	}
+}
+
+// fieldPath 去掉命名空间中的结构体名，例如 CreatePostRequest.tags[0] -> tags[0]。
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), unit)
+	case "max":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), unit)
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
@@ -1,32 +1,243 @@
 package config
 
 import (
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 运行环境，由 APP_ENV 指定，决定加载哪个 profile 文件以及校验的严格程度。
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+// 仅用于本地开发的默认值，生产环境使用它们时拒绝启动。
+const (
+	defaultJWTSecret  = "e4sBKF1JiO7hW0lgnwz8meRVV6r+gfIl5JJXzwsptg0="
+	defaultDBPassword = "123456"
 )
 
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	JWTSecret  string
-	ServerPort string
+	Env                string
+	DBDriver           string
+	DBHost             string
+	DBPort             string
+	DBUser             string
+	DBPassword         string
+	DBName             string
+	DBSSLMode          string
+	DBPath             string
+	AutoMigrate        bool
+	JWTSecret          string
+	AccessTokenTTL     time.Duration
+	RefreshTokenTTL    time.Duration
+	ServerPort         string
+	ReadTimeout        time.Duration
+	WriteTimeout       time.Duration
+	IdleTimeout        time.Duration
+	ShutdownTimeout    time.Duration
+	HealthCheckTimeout time.Duration
+	AdminEmail         string
+	CommentMaxDepth    int
+	PublishInterval    time.Duration
+	LogLevel           string
+	LogFormat          string
+	SlowQueryThreshold time.Duration
+	TrustedProxies     []string
+	AuthRateLimit      Rate
+	LoginRateLimit     Rate
+	PostRateLimit      Rate
+	CommentRateLimit   Rate
+	LockoutThreshold   int
+	LockoutDuration    time.Duration
+	LockoutMaxDuration time.Duration
+	LockoutWindow      time.Duration
+	PublicURL          string
+	MailDriver         string
+	MailFrom           string
+	MailOutboxDir      string
+	SMTPHost           string
+	SMTPPort           string
+	SMTPUsername       string
+	SMTPPassword       string
+	EmailVerifyTTL     time.Duration
+	PasswordResetTTL   time.Duration
+	RequireVerified    bool
+	ViewDedupWindow    time.Duration
+	ViewFlushInterval  time.Duration
+	TrendingHalfLife   time.Duration
+	FeedCacheTTL       time.Duration
+	FeedCacheSize      int
+}
+
+// Rate 是 "次数/时间" 形式的频率，例如 10/1m，Count 为 0 表示不限制。
+type Rate struct {
+	Count int
+	Per   time.Duration
+}
+
+func (r Rate) String() string {
+	if r.Count == 0 {
+		return "0"
+	}
+	return strconv.Itoa(r.Count) + "/" + r.Per.String()
+}
+
+// LoadConfig 按以下顺序加载配置，后者覆盖前者：
+//
+//  1. 内置默认值
+//  2. CONFIG_DIR（默认当前目录）下的 config.yaml / config.yml / config.toml
+//  3. 同目录下当前环境的 profile，例如 config.production.yaml
+//  4. CONFIG_FILE 指定的文件
+//  5. 环境变量
+//
+// 文件中的键与环境变量同名，可以按 "_" 分组嵌套，例如 db.driver 对应 DB_DRIVER。
+// 加载后会校验配置，无法解析的值和不合法的配置都会返回错误。
+func LoadConfig() (*Config, error) {
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = EnvDevelopment
+	}
+
+	l, err := newLoader(env, getenvDefault("CONFIG_DIR", "."), os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Env:                env,
+		DBDriver:           l.getString("DB_DRIVER", "mysql"),
+		DBHost:             l.getString("DB_HOST", "localhost"),
+		DBPort:             l.getString("DB_PORT", "3306"),
+		DBUser:             l.getString("DB_USER", "root"),
+		DBPassword:         l.getString("DB_PASSWORD", defaultDBPassword),
+		DBName:             l.getString("DB_NAME", "blog_db"),
+		DBSSLMode:          l.getString("DB_SSLMODE", "disable"),
+		DBPath:             l.getString("DB_PATH", "blog.db"),
+		AutoMigrate:        l.getBool("AUTO_MIGRATE", true),
+		JWTSecret:          l.getString("JWT_SECRET", defaultJWTSecret),
+		AccessTokenTTL:     l.getDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:    l.getDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		ServerPort:         l.getString("SERVER_PORT", "8080"),
+		ReadTimeout:        l.getDuration("SERVER_READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:       l.getDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:        l.getDuration("SERVER_IDLE_TIMEOUT", time.Minute),
+		ShutdownTimeout:    l.getDuration("SHUTDOWN_TIMEOUT", 20*time.Second),
+		HealthCheckTimeout: l.getDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		AdminEmail:         l.getString("ADMIN_EMAIL", ""),
+		CommentMaxDepth:    l.getInt("COMMENT_MAX_DEPTH", 5),
+		PublishInterval:    l.getDuration("PUBLISH_INTERVAL", time.Minute),
+		LogLevel:           l.getString("LOG_LEVEL", "info"),
+		LogFormat:          l.getString("LOG_FORMAT", "json"),
+		SlowQueryThreshold: l.getDuration("SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+		TrustedProxies:     l.getList("TRUSTED_PROXIES", nil),
+		AuthRateLimit:      l.getRate("RATE_LIMIT_AUTH", Rate{Count: 20, Per: time.Minute}),
+		LoginRateLimit:     l.getRate("RATE_LIMIT_LOGIN", Rate{Count: 10, Per: time.Minute}),
+		PostRateLimit:      l.getRate("RATE_LIMIT_POSTS", Rate{Count: 10, Per: time.Hour}),
+		CommentRateLimit:   l.getRate("RATE_LIMIT_COMMENTS", Rate{Count: 30, Per: 10 * time.Minute}),
+		LockoutThreshold:   l.getInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+		LockoutDuration:    l.getDuration("LOGIN_LOCKOUT_DURATION", time.Minute),
+		LockoutMaxDuration: l.getDuration("LOGIN_LOCKOUT_MAX_DURATION", time.Hour),
+		LockoutWindow:      l.getDuration("LOGIN_LOCKOUT_WINDOW", 15*time.Minute),
+		PublicURL:          strings.TrimRight(l.getString("PUBLIC_URL", "http://localhost:8080"), "/"),
+		MailDriver:         l.getString("MAIL_DRIVER", "log"),
+		MailFrom:           l.getString("MAIL_FROM", "Blog <no-reply@localhost>"),
+		MailOutboxDir:      l.getString("MAIL_OUTBOX_DIR", "outbox"),
+		SMTPHost:           l.getString("SMTP_HOST", ""),
+		SMTPPort:           l.getString("SMTP_PORT", "587"),
+		SMTPUsername:       l.getString("SMTP_USERNAME", ""),
+		SMTPPassword:       l.getString("SMTP_PASSWORD", ""),
+		EmailVerifyTTL:     l.getDuration("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+		PasswordResetTTL:   l.getDuration("PASSWORD_RESET_TTL", time.Hour),
+		RequireVerified:    l.getBool("REQUIRE_VERIFIED_EMAIL", false),
+		ViewDedupWindow:    l.getDuration("VIEW_DEDUP_WINDOW", 30*time.Minute),
+		ViewFlushInterval:  l.getDuration("VIEW_FLUSH_INTERVAL", 10*time.Second),
+		TrendingHalfLife:   l.getDuration("TRENDING_HALF_LIFE", 6*time.Hour),
+		FeedCacheTTL:       l.getDuration("FEED_CACHE_TTL", 5*time.Minute),
+		FeedCacheSize:      l.getInt("FEED_CACHE_SIZE", 10000),
+	}
+
+	if err := l.finish(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) IsProduction() bool {
+	return c.Env == EnvProduction
+}
+
+// LogValue 实现 slog.LogValuer，输出配置时隐藏密码和密钥。
+func (c *Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("env", c.Env),
+		slog.String("db_driver", c.DBDriver),
+		slog.String("db_host", c.DBHost),
+		slog.String("db_port", c.DBPort),
+		slog.String("db_user", c.DBUser),
+		slog.String("db_password", redact(c.DBPassword)),
+		slog.String("db_name", c.DBName),
+		slog.String("db_sslmode", c.DBSSLMode),
+		slog.String("db_path", c.DBPath),
+		slog.Bool("auto_migrate", c.AutoMigrate),
+		slog.String("jwt_secret", redact(c.JWTSecret)),
+		slog.String("access_token_ttl", c.AccessTokenTTL.String()),
+		slog.String("refresh_token_ttl", c.RefreshTokenTTL.String()),
+		slog.String("server_port", c.ServerPort),
+		slog.String("server_read_timeout", c.ReadTimeout.String()),
+		slog.String("server_write_timeout", c.WriteTimeout.String()),
+		slog.String("server_idle_timeout", c.IdleTimeout.String()),
+		slog.String("shutdown_timeout", c.ShutdownTimeout.String()),
+		slog.String("health_check_timeout", c.HealthCheckTimeout.String()),
+		slog.String("admin_email", c.AdminEmail),
+		slog.Int("comment_max_depth", c.CommentMaxDepth),
+		slog.String("publish_interval", c.PublishInterval.String()),
+		slog.String("log_level", c.LogLevel),
+		slog.String("log_format", c.LogFormat),
+		slog.String("slow_query_threshold", c.SlowQueryThreshold.String()),
+		slog.String("trusted_proxies", strings.Join(c.TrustedProxies, ",")),
+		slog.String("rate_limit_auth", c.AuthRateLimit.String()),
+		slog.String("rate_limit_login", c.LoginRateLimit.String()),
+		slog.String("rate_limit_posts", c.PostRateLimit.String()),
+		slog.String("rate_limit_comments", c.CommentRateLimit.String()),
+		slog.Int("login_lockout_threshold", c.LockoutThreshold),
+		slog.String("login_lockout_duration", c.LockoutDuration.String()),
+		slog.String("login_lockout_max_duration", c.LockoutMaxDuration.String()),
+		slog.String("login_lockout_window", c.LockoutWindow.String()),
+		slog.String("public_url", c.PublicURL),
+		slog.String("mail_driver", c.MailDriver),
+		slog.String("mail_from", c.MailFrom),
+		slog.String("mail_outbox_dir", c.MailOutboxDir),
+		slog.String("smtp_host", c.SMTPHost),
+		slog.String("smtp_port", c.SMTPPort),
+		slog.String("smtp_username", c.SMTPUsername),
+		slog.String("smtp_password", redact(c.SMTPPassword)),
+		slog.String("email_verification_ttl", c.EmailVerifyTTL.String()),
+		slog.String("password_reset_ttl", c.PasswordResetTTL.String()),
+		slog.Bool("require_verified_email", c.RequireVerified),
+		slog.String("view_dedup_window", c.ViewDedupWindow.String()),
+		slog.String("view_flush_interval", c.ViewFlushInterval.String()),
+		slog.String("trending_half_life", c.TrendingHalfLife.String()),
+		slog.String("feed_cache_ttl", c.FeedCacheTTL.String()),
+		slog.Int("feed_cache_size", c.FeedCacheSize),
+	)
 }
 
-func LoadConfig() *Config {
-	return &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "3306"),
-		DBUser:     getEnv("DB_USER", "root"),
-		DBPassword: getEnv("DB_PASSWORD", "123456"),
-		DBName:     getEnv("DB_NAME", "blog_db"),
-		JWTSecret:  getEnv("JWT_SECRET", "e4sBKF1JiO7hW0lgnwz8meRVV6r+gfIl5JJXzwsptg0="),
-		ServerPort: getEnv("SERVER_PORT", "8080"),
+func redact(secret string) string {
+	if secret == "" {
+		return ""
 	}
+	return "[REDACTED]"
 }
 
-func getEnv(key, defaultValue string) string {
+func getenvDefault(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
 	}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "config.yaml", `
+db:
+  driver: sqlite
+  path: base.db
+access_token_ttl: 10m
+comment_max_depth: 3
+log:
+  level: debug
+`)
+	writeFile(t, dir, "config.test.toml", `
+access_token_ttl = "5m"
+
+[db]
+path = "test.db"
+`)
+	t.Setenv("APP_ENV", EnvTest)
+	t.Setenv("CONFIG_DIR", dir)
+	t.Setenv("COMMENT_MAX_DEPTH", "7")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	if cfg.Env != EnvTest || cfg.DBDriver != "sqlite" || cfg.LogLevel != "debug" {
+		t.Errorf("base file not applied: env=%q driver=%q level=%q", cfg.Env, cfg.DBDriver, cfg.LogLevel)
+	}
+	if cfg.DBPath != "test.db" || cfg.AccessTokenTTL != 5*time.Minute {
+		t.Errorf("profile not applied: path=%q ttl=%v", cfg.DBPath, cfg.AccessTokenTTL)
+	}
+	if cfg.CommentMaxDepth != 7 {
+		t.Errorf("env override not applied: depth=%d", cfg.CommentMaxDepth)
+	}
+	if cfg.RefreshTokenTTL != 7*24*time.Hour {
+		t.Errorf("default not applied: refresh ttl=%v", cfg.RefreshTokenTTL)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "config.yaml", `
+access_token_ttl: forever
+db:
+  drvier: sqlite
+`)
+	t.Setenv("APP_ENV", EnvDevelopment)
+	t.Setenv("CONFIG_DIR", dir)
+	t.Setenv("AUTO_MIGRATE", "maybe")
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("LoadConfig succeeded, want error")
+	}
+	for _, want := range []string{"ACCESS_TOKEN_TTL: invalid duration", "AUTO_MIGRATE: invalid boolean", "DB_DRVIER: unknown config key"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func validConfig() *Config {
+	return &Config{
+		Env:                EnvProduction,
+		DBDriver:           "mysql",
+		DBPassword:         "s3cret-password",
+		JWTSecret:          strings.Repeat("k", MinJWTSecretLength),
+		AccessTokenTTL:     15 * time.Minute,
+		RefreshTokenTTL:    time.Hour,
+		ServerPort:         "8080",
+		ReadTimeout:        time.Second,
+		WriteTimeout:       time.Second,
+		IdleTimeout:        time.Second,
+		ShutdownTimeout:    time.Second,
+		HealthCheckTimeout: time.Second,
+		CommentMaxDepth:    5,
+		PublishInterval:    time.Minute,
+		LogLevel:           "info",
+		LogFormat:          "json",
+		PublicURL:          "https://blog.example.com",
+		MailDriver:         "smtp",
+		SMTPHost:           "smtp.example.com",
+		MailFrom:           "Blog <no-reply@example.com>",
+		EmailVerifyTTL:     time.Hour,
+		PasswordResetTTL:   time.Hour,
+		ViewDedupWindow:    time.Minute,
+		ViewFlushInterval:  time.Second,
+		TrendingHalfLife:   time.Hour,
+		FeedCacheTTL:       time.Minute,
+		FeedCacheSize:      100,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"valid", func(*Config) {}, ""},
+		{"default secret in production", func(c *Config) { c.JWTSecret = defaultJWTSecret }, "JWT_SECRET: the built-in development secret"},
+		{"short secret in production", func(c *Config) { c.JWTSecret = "short" }, "JWT_SECRET: must be at least"},
+		{"default db password in production", func(c *Config) { c.DBPassword = defaultDBPassword }, "DB_PASSWORD"},
+		{"default secret in development", func(c *Config) { c.Env = EnvDevelopment; c.JWTSecret = defaultJWTSecret }, ""},
+		{"unknown driver", func(c *Config) { c.DBDriver = "oracle" }, "DB_DRIVER"},
+		{"bad port", func(c *Config) { c.ServerPort = "http" }, "SERVER_PORT"},
+		{"zero timeout", func(c *Config) { c.ShutdownTimeout = 0 }, "SHUTDOWN_TIMEOUT"},
+		{"unknown log level", func(c *Config) { c.LogLevel = "loud" }, "LOG_LEVEL"},
+		{"unknown env", func(c *Config) { c.Env = "staging" }, "APP_ENV"},
+		{"smtp without host", func(c *Config) { c.SMTPHost = "" }, "SMTP_HOST"},
+		{"log mailer in production", func(c *Config) { c.MailDriver = "log" }, "MAIL_DRIVER"},
+		{"relative public url", func(c *Config) { c.PublicURL = "/blog" }, "PUBLIC_URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Validate = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogValueRedactsSecrets(t *testing.T) {
+	cfg := validConfig()
+	cfg.SMTPPassword = "smtp-password"
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "config", cfg)
+
+	out := buf.String()
+	if strings.Contains(out, cfg.JWTSecret) || strings.Contains(out, cfg.DBPassword) || strings.Contains(out, cfg.SMTPPassword) {
+		t.Errorf("secrets leaked in %s", out)
+	}
+	if !strings.Contains(out, `"jwt_secret":"[REDACTED]"`) || !strings.Contains(out, `"db_driver":"mysql"`) {
+		t.Errorf("unexpected dump %s", out)
+	}
+}
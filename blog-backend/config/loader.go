@@ -0,0 +1,227 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// configExts 是支持的配置文件扩展名，同一层只加载找到的第一个。
+var configExts = []string{".yaml", ".yml", ".toml"}
+
+// loader 合并配置文件和环境变量，并收集解析过程中的错误。
+type loader struct {
+	file map[string]string
+	// source 记录文件中每个键来自哪个文件，用于错误提示
+	source map[string]string
+	used   map[string]bool
+	errs   []error
+}
+
+func newLoader(env, dir, explicit string) (*loader, error) {
+	l := &loader{
+		file:   make(map[string]string),
+		source: make(map[string]string),
+		used:   make(map[string]bool),
+	}
+
+	for _, name := range []string{"config", "config." + env} {
+		path, ok := findConfigFile(dir, name)
+		if !ok {
+			continue
+		}
+		if err := l.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if explicit != "" {
+		if err := l.loadFile(explicit); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func findConfigFile(dir, name string) (string, bool) {
+	for _, ext := range configExts {
+		path := filepath.Join(dir, name+ext)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func (l *loader) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		return fmt.Errorf("config file %s: unsupported format, expected .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	return flatten("", raw, func(key, value string) {
+		l.file[key] = value
+		l.source[key] = path
+	}, path)
+}
+
+// flatten 把嵌套的键展开为环境变量形式，例如 {db: {driver: x}} -> DB_DRIVER=x。
+func flatten(prefix string, raw map[string]interface{}, set func(key, value string), path string) error {
+	for k, v := range raw {
+		key := strings.ToUpper(strings.ReplaceAll(k, "-", "_"))
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		switch value := v.(type) {
+		case map[string]interface{}:
+			if err := flatten(key, value, set, path); err != nil {
+				return err
+			}
+		case []interface{}:
+			return fmt.Errorf("config file %s: %s: lists are not supported", path, key)
+		case nil:
+			set(key, "")
+		default:
+			set(key, fmt.Sprint(value))
+		}
+	}
+	return nil
+}
+
+// lookup 优先返回环境变量，其次是配置文件中的值。
+func (l *loader) lookup(key string) (string, bool) {
+	l.used[key] = true
+	if value, exists := os.LookupEnv(key); exists {
+		return value, true
+	}
+	value, exists := l.file[key]
+	return value, exists
+}
+
+func (l *loader) invalid(key, value, kind string) {
+	origin := "environment"
+	if _, fromEnv := os.LookupEnv(key); !fromEnv {
+		origin = l.source[key]
+	}
+	l.errs = append(l.errs, fmt.Errorf("%s: invalid %s %q (from %s)", key, kind, value, origin))
+}
+
+func (l *loader) getString(key, defaultValue string) string {
+	if value, exists := l.lookup(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+func (l *loader) getDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := l.lookup(key)
+	if !exists {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		l.invalid(key, value, "duration")
+		return defaultValue
+	}
+	return d
+}
+
+func (l *loader) getInt(key string, defaultValue int) int {
+	value, exists := l.lookup(key)
+	if !exists {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		l.invalid(key, value, "integer")
+		return defaultValue
+	}
+	return i
+}
+
+func (l *loader) getBool(key string, defaultValue bool) bool {
+	value, exists := l.lookup(key)
+	if !exists {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.invalid(key, value, "boolean")
+		return defaultValue
+	}
+	return b
+}
+
+// getList 解析逗号分隔的列表，忽略空白项。
+func (l *loader) getList(key string, defaultValue []string) []string {
+	value, exists := l.lookup(key)
+	if !exists {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getRate 解析 "次数/时间" 形式的频率，例如 10/1m；"0" 表示不限制。
+func (l *loader) getRate(key string, defaultValue Rate) Rate {
+	value, exists := l.lookup(key)
+	if !exists {
+		return defaultValue
+	}
+	if strings.TrimSpace(value) == "0" {
+		return Rate{}
+	}
+
+	count, per, ok := strings.Cut(value, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if !ok || err != nil || n < 0 {
+		l.invalid(key, value, "rate")
+		return defaultValue
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(per))
+	if err != nil || d <= 0 {
+		l.invalid(key, value, "rate")
+		return defaultValue
+	}
+	return Rate{Count: n, Per: d}
+}
+
+// finish 返回解析错误，并拒绝配置文件中的未知键，避免拼写错误被静默忽略。
+func (l *loader) finish() error {
+	var unknown []string
+	for key := range l.file {
+		if !l.used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		l.errs = append(l.errs, fmt.Errorf("%s: unknown config key (from %s)", key, l.source[key]))
+	}
+	return errors.Join(l.errs...)
+}
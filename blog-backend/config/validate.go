@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinJWTSecretLength 是 HS256 密钥的最小长度（字节），与哈希输出长度一致。
+const MinJWTSecretLength = 32
+
+// Validate 检查配置是否合法，返回所有问题。生产环境额外要求替换默认的 JWT 密钥和数据库密码。
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.Env {
+	case EnvDevelopment, EnvTest, EnvProduction:
+	default:
+		fail("APP_ENV: unknown environment %q, expected development, test or production", c.Env)
+	}
+
+	switch c.DBDriver {
+	case "mysql", "postgres", "sqlite":
+	default:
+		fail("DB_DRIVER: unsupported driver %q, expected mysql, postgres or sqlite", c.DBDriver)
+	}
+
+	if port, err := strconv.Atoi(c.ServerPort); err != nil || port < 1 || port > 65535 {
+		fail("SERVER_PORT: invalid port %q", c.ServerPort)
+	}
+
+	positive := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"ACCESS_TOKEN_TTL", c.AccessTokenTTL},
+		{"REFRESH_TOKEN_TTL", c.RefreshTokenTTL},
+		{"SERVER_READ_TIMEOUT", c.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", c.ShutdownTimeout},
+		{"HEALTH_CHECK_TIMEOUT", c.HealthCheckTimeout},
+		{"PUBLISH_INTERVAL", c.PublishInterval},
+		{"EMAIL_VERIFICATION_TTL", c.EmailVerifyTTL},
+		{"PASSWORD_RESET_TTL", c.PasswordResetTTL},
+		{"VIEW_DEDUP_WINDOW", c.ViewDedupWindow},
+		{"VIEW_FLUSH_INTERVAL", c.ViewFlushInterval},
+		{"TRENDING_HALF_LIFE", c.TrendingHalfLife},
+		{"FEED_CACHE_TTL", c.FeedCacheTTL},
+	}
+	for _, p := range positive {
+		if p.value <= 0 {
+			fail("%s: must be positive", p.key)
+		}
+	}
+	if c.RefreshTokenTTL < c.AccessTokenTTL {
+		fail("REFRESH_TOKEN_TTL: must not be shorter than ACCESS_TOKEN_TTL")
+	}
+	if c.SlowQueryThreshold < 0 {
+		fail("SLOW_QUERY_THRESHOLD: must not be negative")
+	}
+	if c.LockoutThreshold < 0 || c.LockoutDuration < 0 || c.LockoutMaxDuration < 0 || c.LockoutWindow < 0 {
+		fail("LOGIN_LOCKOUT_*: must not be negative")
+	}
+	if c.CommentMaxDepth < 1 {
+		fail("COMMENT_MAX_DEPTH: must be at least 1")
+	}
+	if c.FeedCacheSize < 1 {
+		fail("FEED_CACHE_SIZE: must be at least 1")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		fail("LOG_LEVEL: unknown level %q, expected debug, info, warn or error", c.LogLevel)
+	}
+	switch strings.ToLower(c.LogFormat) {
+	case "json", "text":
+	default:
+		fail("LOG_FORMAT: unknown format %q, expected json or text", c.LogFormat)
+	}
+
+	switch c.MailDriver {
+	case "log", "file":
+	case "smtp":
+		if c.SMTPHost == "" {
+			fail("SMTP_HOST: required when MAIL_DRIVER is smtp")
+		}
+	default:
+		fail("MAIL_DRIVER: unknown driver %q, expected log, file or smtp", c.MailDriver)
+	}
+	if _, err := mail.ParseAddress(c.MailFrom); err != nil {
+		fail("MAIL_FROM: invalid address %q", c.MailFrom)
+	}
+	if u, err := url.Parse(c.PublicURL); err != nil || u.Scheme == "" || u.Host == "" {
+		fail("PUBLIC_URL: must be an absolute URL, got %q", c.PublicURL)
+	}
+
+	if c.JWTSecret == "" {
+		fail("JWT_SECRET: must not be empty")
+	}
+	if c.IsProduction() {
+		if c.JWTSecret == defaultJWTSecret {
+			fail("JWT_SECRET: the built-in development secret must not be used in production")
+		}
+		if len(c.JWTSecret) < MinJWTSecretLength {
+			fail("JWT_SECRET: must be at least %d bytes in production", MinJWTSecretLength)
+		}
+		if c.DBDriver != "sqlite" && c.DBPassword == defaultDBPassword {
+			fail("DB_PASSWORD: the built-in development password must not be used in production")
+		}
+		if c.MailDriver == "log" {
+			fail("MAIL_DRIVER: the log driver writes one-time tokens to the log and must not be used in production")
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Warnings 返回不影响启动但需要注意的问题，例如开发环境仍在使用默认密钥。
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if c.IsProduction() {
+		return warnings
+	}
+	if c.JWTSecret == defaultJWTSecret {
+		warnings = append(warnings, "JWT_SECRET is the built-in development secret; set a random value before deploying")
+	} else if len(c.JWTSecret) < MinJWTSecretLength {
+		warnings = append(warnings, fmt.Sprintf("JWT_SECRET is shorter than %d bytes and would be rejected in production", MinJWTSecretLength))
+	}
+	return warnings
+}
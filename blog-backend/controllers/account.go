@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type AccountController struct {
+	svc *service.AccountService
+}
+
+func NewAccountController(svc *service.AccountService) *AccountController {
+	return &AccountController{svc: svc}
+}
+
+// VerifyEmail 使用邮件中的令牌确认邮箱。
+func (ac *AccountController) VerifyEmail(c *gin.Context) {
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	user, err := ac.svc.VerifyEmail(c.Request.Context(), req.Token)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to verify email"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Email verified successfully", user)
+}
+
+// ResendVerification 重新向当前用户发送验证邮件，之前的验证链接随之失效。
+func (ac *AccountController) ResendVerification(c *gin.Context) {
+	if err := ac.svc.SendVerification(c.Request.Context(), middleware.CurrentUser(c)); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to send verification email"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Verification email sent", nil)
+}
+
+// ForgotPassword 发送重置密码邮件。无论邮箱是否注册都返回相同的响应。
+func (ac *AccountController) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	if err := ac.svc.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to send password reset email"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword 使用重置令牌设置新密码，成功后需要重新登录。
+func (ac *AccountController) ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	if err := ac.svc.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to reset password"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset successfully, please log in again", nil)
+}
@@ -4,97 +4,85 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/task/go_learn_task/blog-backend/config"
-	"github.com/task/go_learn_task/blog-backend/database"
+	"github.com/task/go_learn_task/blog-backend/apperror"
 	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
 	"github.com/task/go_learn_task/blog-backend/utils"
 )
 
 type AuthController struct {
-	cfg *config.Config
+	svc *service.AuthService
 }
 
-func NewAuthController(cfg *config.Config) *AuthController {
-	return &AuthController{cfg: cfg}
-}
-
-type RegisterRequest struct {
-	Username string `json:"username" binding:"required,min=3"`
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
-}
-
-type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+func NewAuthController(svc *service.AuthService) *AuthController {
+	return &AuthController{svc: svc}
 }
 
 func (ac *AuthController) Register(c *gin.Context) {
-	var req RegisterRequest
+	var req models.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ValidationErrorResponse(c, "Invalid input")
+		c.Error(apperror.Bind(err))
 		return
 	}
 
-	var existingUser models.User
-	if err := database.DB.Where("email = ? OR username = ?", req.Email, req.Username).First(&existingUser).Error; err == nil {
-		utils.ErrorResponse(c, http.StatusConflict, "User already exists", nil)
+	user, tokens, err := ac.svc.Register(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to create user"))
 		return
 	}
 
-	user := models.User{
-		Username: req.Username,
-		Email:    req.Email,
-	}
-
-	if err := user.HashPassword(req.Password); err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create user", err)
-		return
-	}
+	utils.SuccessResponse(c, http.StatusCreated, "User registered successfully", tokenResponse(user, tokens))
+}
 
-	if err := database.DB.Create(&user).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create user", err)
+func (ac *AuthController) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
 		return
 	}
 
-	token, err := utils.GenerateToken(&user, ac.cfg)
+	user, tokens, err := ac.svc.Login(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token", err)
+		c.Error(apperror.Wrap(err, "Failed to generate token"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "User registered successfully", gin.H{
-		"user":  user,
-		"token": token,
-	})
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", tokenResponse(user, tokens))
 }
 
-func (ac *AuthController) Login(c *gin.Context) {
-	var req LoginRequest
+// RefreshToken 用刷新令牌换取新的访问令牌和刷新令牌（轮换）。
+func (ac *AuthController) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ValidationErrorResponse(c, "Invalid input")
+		c.Error(apperror.Bind(err))
 		return
 	}
 
-	var user models.User
-	if err := database.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid credentials", nil)
+	user, tokens, err := ac.svc.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to refresh token"))
 		return
 	}
 
-	if err := user.CheckPassword(req.Password); err != nil {
-		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid credentials", nil)
-		return
-	}
+	utils.SuccessResponse(c, http.StatusOK, "Token refreshed successfully", tokenResponse(user, tokens))
+}
 
-	token, err := utils.GenerateToken(&user, ac.cfg)
-	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to generate token", err)
+// Logout 吊销当前访问令牌所属的整个会话 family。
+func (ac *AuthController) Logout(c *gin.Context) {
+	if err := ac.svc.Logout(c.Request.Context(), c.GetString("tokenID")); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to logout"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Login successful", gin.H{
-		"user":  user,
-		"token": token,
-	})
+	utils.SuccessResponse(c, http.StatusOK, "Logout successful", nil)
+}
+
+func tokenResponse(user *models.User, tokens *service.TokenPair) gin.H {
+	return gin.H{
+		"user":          user,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    tokens.ExpiresIn,
+	}
 }
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type BookmarkController struct {
+	svc *service.BookmarkService
+}
+
+func NewBookmarkController(svc *service.BookmarkService) *BookmarkController {
+	return &BookmarkController{svc: svc}
+}
+
+func (bc *BookmarkController) AddBookmark(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return
+	}
+
+	bookmark, err := bc.svc.Add(c.Request.Context(), middleware.CurrentUser(c), id)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to bookmark post"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Post bookmarked successfully", bookmark)
+}
+
+func (bc *BookmarkController) RemoveBookmark(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return
+	}
+
+	if err := bc.svc.Remove(c.Request.Context(), middleware.CurrentUser(c), id); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to remove bookmark"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Bookmark removed successfully", nil)
+}
+
+// GetMyBookmarks 按收藏时间倒序分页返回当前用户收藏的文章。
+func (bc *BookmarkController) GetMyBookmarks(c *gin.Context) {
+	params, err := utils.ParsePageParams(c)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := bc.svc.List(c.Request.Context(), middleware.CurrentUser(c), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch bookmarks"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Bookmarks fetched successfully", result)
+}
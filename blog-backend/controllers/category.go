@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type CategoryController struct {
+	svc *service.CategoryService
+}
+
+func NewCategoryController(svc *service.CategoryService) *CategoryController {
+	return &CategoryController{svc: svc}
+}
+
+func (cc *CategoryController) GetCategories(c *gin.Context) {
+	categories, err := cc.svc.List(c.Request.Context())
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch categories"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Categories fetched successfully", categories)
+}
+
+func (cc *CategoryController) CreateCategory(c *gin.Context) {
+	var req models.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	category, err := cc.svc.Create(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to create category"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Category created successfully", category)
+}
+
+func (cc *CategoryController) UpdateCategory(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid category ID"))
+		return
+	}
+
+	var req models.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	category, err := cc.svc.Update(c.Request.Context(), id, &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update category"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Category updated successfully", category)
+}
+
+// DeleteCategory 删除分类，原分类下的文章变为未分类。
+func (cc *CategoryController) DeleteCategory(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid category ID"))
+		return
+	}
+
+	if err := cc.svc.Delete(c.Request.Context(), id); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to delete category"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Category deleted successfully", nil)
+}
@@ -2,77 +2,150 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/task/go_learn_task/blog-backend/database"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
 	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
 	"github.com/task/go_learn_task/blog-backend/utils"
 )
 
-type CommentController struct{}
+type CommentController struct {
+	svc *service.CommentService
+}
 
-func NewCommentController() *CommentController {
-	return &CommentController{}
+func NewCommentController(svc *service.CommentService) *CommentController {
+	return &CommentController{svc: svc}
 }
 
 func (cc *CommentController) CreateComment(c *gin.Context) {
-	userID := c.GetUint("userID")
-	postID, err := strconv.Atoi(c.Param("postId"))
+	postID, ok := parseID(c, "postId")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	comment, err := cc.svc.Create(c.Request.Context(), middleware.CurrentUser(c), postID, &req)
 	if err != nil {
-		utils.ValidationErrorResponse(c, "Invalid post ID")
+		c.Error(apperror.Wrap(err, "Failed to create comment"))
 		return
 	}
 
-	var post models.Post
-	if err := database.DB.First(&post, postID).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Post not found", err)
+	utils.SuccessResponse(c, http.StatusCreated, "Comment created successfully", comment)
+}
+
+// GetPostComments 默认按页返回未删除的评论；format=tree 返回完整的评论树，不分页。
+func (cc *CommentController) GetPostComments(c *gin.Context) {
+	postID, ok := parseID(c, "postId")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
 		return
 	}
 
-	var req models.CreateCommentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ValidationErrorResponse(c, "Invalid input")
+	format := c.DefaultQuery("format", "flat")
+	if format != "flat" && format != "tree" {
+		c.Error(apperror.BadRequest("Invalid format, expected flat or tree"))
 		return
 	}
 
-	comment := models.Comment{
-		Content: req.Content,
-		UserID:  userID,
-		PostID:  uint(postID),
+	viewer := middleware.CurrentUser(c)
+	if format == "tree" {
+		thread, err := cc.svc.Thread(c.Request.Context(), viewer, postID)
+		if err != nil {
+			c.Error(apperror.Wrap(err, "Failed to fetch comments"))
+			return
+		}
+		utils.SuccessResponse(c, http.StatusOK, "Comments fetched successfully", thread)
+		return
 	}
 
-	if err := database.DB.Create(&comment).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create comment", err)
+	params, err := utils.ParsePageParams(c)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
 		return
 	}
+	if c.Query("sort") == "" && params.Cursor == nil {
+		params.SortDesc = false
+	}
 
-	if err := database.DB.Preload("User").First(&comment, comment.ID).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch comment", err)
+	result, err := cc.svc.List(c.Request.Context(), viewer, postID, params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch comments"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "Comment created successfully", comment)
+	utils.SuccessResponse(c, http.StatusOK, "Comments fetched successfully", result)
 }
 
-func (cc *CommentController) GetPostComments(c *gin.Context) {
-	postID, err := strconv.Atoi(c.Param("postId"))
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+	postID, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	comment, err := cc.svc.Update(c.Request.Context(), middleware.CurrentUser(c), postID, commentID, &req)
 	if err != nil {
-		utils.ValidationErrorResponse(c, "Invalid post ID")
+		c.Error(apperror.Wrap(err, "Failed to update comment"))
 		return
 	}
 
-	var post models.Post
-	if err := database.DB.First(&post, postID).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Post not found", err)
+	utils.SuccessResponse(c, http.StatusOK, "Comment updated successfully", comment)
+}
+
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	postID, commentID, ok := parseCommentPath(c)
+	if !ok {
 		return
 	}
 
-	var comments []models.Comment
-	if err := database.DB.Preload("User").Where("post_id = ?", postID).Find(&comments).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch comments", err)
+	if err := cc.svc.Delete(c.Request.Context(), middleware.CurrentUser(c), postID, commentID); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to delete comment"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Comments fetched successfully", comments)
+	utils.SuccessResponse(c, http.StatusOK, "Comment deleted successfully", nil)
+}
+
+// RestoreComment 恢复已删除的评论，路由上要求 PermDeleteAnyComment。
+func (cc *CommentController) RestoreComment(c *gin.Context) {
+	postID, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+
+	comment, err := cc.svc.Restore(c.Request.Context(), postID, commentID)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to restore comment"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Comment restored successfully", comment)
+}
+
+// parseCommentPath 解析路由中的 postId 和 commentId，出错时已写入响应。
+func parseCommentPath(c *gin.Context) (uint, uint, bool) {
+	postID, ok := parseID(c, "postId")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return 0, 0, false
+	}
+	commentID, ok := parseID(c, "commentId")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid comment ID"))
+		return 0, 0, false
+	}
+	return postID, commentID, true
 }
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type FollowController struct {
+	follows *service.FollowService
+	feed    *service.FeedService
+}
+
+func NewFollowController(follows *service.FollowService, feed *service.FeedService) *FollowController {
+	return &FollowController{follows: follows, feed: feed}
+}
+
+func (fc *FollowController) Follow(c *gin.Context) {
+	profile, err := fc.follows.Follow(c.Request.Context(), middleware.CurrentUser(c), c.Param("username"))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to follow user"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "User followed successfully", profile)
+}
+
+func (fc *FollowController) Unfollow(c *gin.Context) {
+	profile, err := fc.follows.Unfollow(c.Request.Context(), middleware.CurrentUser(c), c.Param("username"))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to unfollow user"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User unfollowed successfully", profile)
+}
+
+// GetFollowers 按关注时间倒序分页返回用户的粉丝。
+func (fc *FollowController) GetFollowers(c *gin.Context) {
+	params, err := utils.ParsePageParams(c)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := fc.follows.Followers(c.Request.Context(), c.Param("username"), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch followers"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Followers fetched successfully", result)
+}
+
+// GetFollowing 按关注时间倒序分页返回用户关注的人。
+func (fc *FollowController) GetFollowing(c *gin.Context) {
+	params, err := utils.ParsePageParams(c)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := fc.follows.Following(c.Request.Context(), c.Param("username"), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch following"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Following fetched successfully", result)
+}
+
+// GetFeed 返回当前用户关注的作者发布的文章，最新的在前，翻页使用 next_cursor。
+func (fc *FollowController) GetFeed(c *gin.Context) {
+	params, err := utils.ParsePageParams(c)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := fc.feed.Feed(c.Request.Context(), middleware.CurrentUser(c), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch feed"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Feed fetched successfully", result)
+}
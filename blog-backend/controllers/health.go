@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/health"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type HealthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) *HealthController {
+	return &HealthController{checker: checker}
+}
+
+// Livez 只表示进程能够处理请求，不检查依赖，依赖故障时不应导致重启。
+func (hc *HealthController) Livez(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "Server is running", nil)
+}
+
+// Readyz 检查数据库等依赖，任意一项不可用时返回 503，负载均衡应暂停转发流量。
+func (hc *HealthController) Readyz(c *gin.Context) {
+	report := hc.checker.Run(c.Request.Context())
+	if !report.Healthy() {
+		for name, result := range report.Checks {
+			if result.Err != nil {
+				slog.WarnContext(c.Request.Context(), "readiness check failed", "check", name, "error", result.Err.Error())
+			}
+		}
+		// 失败时同样在 data 中返回各项检查结果，格式与成功时一致
+		c.JSON(http.StatusServiceUnavailable, utils.Response{
+			Success: false,
+			Message: "Service not ready",
+			Error:   "Service not ready",
+			Code:    apperror.CodeUnavailable,
+			Data:    report,
+		})
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Service ready", report)
+}
@@ -2,42 +2,36 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/task/go_learn_task/blog-backend/database"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
 	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
 	"github.com/task/go_learn_task/blog-backend/utils"
 )
 
-type PostController struct{}
+// postSortFields 是文章列表允许的排序字段（created_at 总是允许）。
+var postSortFields = []string{"updated_at", "published_at", "title"}
 
-func NewPostController() *PostController {
-	return &PostController{}
+type PostController struct {
+	svc *service.PostService
 }
 
-func (pc *PostController) CreatePost(c *gin.Context) {
-	userID := c.GetUint("userID")
+func NewPostController(svc *service.PostService) *PostController {
+	return &PostController{svc: svc}
+}
 
+func (pc *PostController) CreatePost(c *gin.Context) {
 	var req models.CreatePostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ValidationErrorResponse(c, "Invalid input")
-		return
-	}
-
-	post := models.Post{
-		Title:   req.Title,
-		Content: req.Content,
-		UserID:  userID,
-	}
-
-	if err := database.DB.Create(&post).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create post", err)
+		c.Error(apperror.Bind(err))
 		return
 	}
 
-	if err := database.DB.Preload("User").First(&post, post.ID).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch post", err)
+	post, err := pc.svc.Create(c.Request.Context(), middleware.CurrentUser(c), &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to create post"))
 		return
 	}
 
@@ -45,109 +39,130 @@ func (pc *PostController) CreatePost(c *gin.Context) {
 }
 
 func (pc *PostController) GetAllPosts(c *gin.Context) {
-	var posts []models.Post
-
-	query := database.DB.Preload("User")
-
-	// 分页参数
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset := (page - 1) * limit
-
-	query = query.Limit(limit).Offset(offset)
+	params, err := utils.ParsePageParams(c, postSortFields...)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
 
-	if err := query.Find(&posts).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch posts", err)
+	result, err := pc.svc.ListPublished(c.Request.Context(), middleware.CurrentUser(c), c.Query("tag"), c.Query("category"), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch posts"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Posts fetched successfully", posts)
+	utils.SuccessResponse(c, http.StatusOK, "Posts fetched successfully", result)
 }
 
 func (pc *PostController) GetPost(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		utils.ValidationErrorResponse(c, "Invalid post ID")
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
 		return
 	}
 
-	var post models.Post
-	if err := database.DB.Preload("User").Preload("Comments.User").First(&post, id).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Post not found", err)
+	viewer := middleware.CurrentUser(c)
+	post, err := pc.svc.Get(c.Request.Context(), viewer, id)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch post"))
 		return
 	}
+	pc.svc.RecordView(post, viewer, c.ClientIP())
 
 	utils.SuccessResponse(c, http.StatusOK, "Post fetched successfully", post)
 }
 
 func (pc *PostController) UpdatePost(c *gin.Context) {
-	userID := c.GetUint("userID")
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		utils.ValidationErrorResponse(c, "Invalid post ID")
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
 		return
 	}
 
-	var post models.Post
-	if err := database.DB.First(&post, id).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Post not found", err)
+	var req models.UpdatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
 		return
 	}
 
-	if post.UserID != userID {
-		utils.ErrorResponse(c, http.StatusForbidden, "You can only update your own posts", nil)
+	post, err := pc.svc.Update(c.Request.Context(), middleware.CurrentUser(c), id, &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update post"))
 		return
 	}
 
-	var req models.UpdatePostRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ValidationErrorResponse(c, "Invalid input")
+	utils.SuccessResponse(c, http.StatusOK, "Post updated successfully", post)
+}
+
+func (pc *PostController) DeletePost(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
 		return
 	}
 
-	if req.Title != "" {
-		post.Title = req.Title
-	}
-	if req.Content != "" {
-		post.Content = req.Content
+	if err := pc.svc.Delete(c.Request.Context(), middleware.CurrentUser(c), id); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to delete post"))
+		return
 	}
 
-	if err := database.DB.Save(&post).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update post", err)
+	utils.SuccessResponse(c, http.StatusOK, "Post deleted successfully", nil)
+}
+
+// RestorePost 恢复已删除的文章，路由上要求 PermDeleteAnyPost。
+func (pc *PostController) RestorePost(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
 		return
 	}
 
-	if err := database.DB.Preload("User").First(&post, post.ID).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch post", err)
+	post, err := pc.svc.Restore(c.Request.Context(), id)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to restore post"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Post updated successfully", post)
+	utils.SuccessResponse(c, http.StatusOK, "Post restored successfully", post)
 }
 
-func (pc *PostController) DeletePost(c *gin.Context) {
-	userID := c.GetUint("userID")
-	id, err := strconv.Atoi(c.Param("id"))
+// GetMyPosts 返回当前用户自己的文章，可通过 status 过滤草稿、已发布或归档文章。
+func (pc *PostController) GetMyPosts(c *gin.Context) {
+	params, err := utils.ParsePageParams(c, postSortFields...)
 	if err != nil {
-		utils.ValidationErrorResponse(c, "Invalid post ID")
+		c.Error(apperror.BadRequest(err.Error()))
 		return
 	}
 
-	var post models.Post
-	if err := database.DB.First(&post, id).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Post not found", err)
+	result, err := pc.svc.ListByAuthor(c.Request.Context(), middleware.CurrentUser(c), c.Query("status"), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch posts"))
 		return
 	}
 
-	if post.UserID != userID {
-		utils.ErrorResponse(c, http.StatusForbidden, "You can only delete your own posts", nil)
+	utils.SuccessResponse(c, http.StatusOK, "Posts fetched successfully", result)
+}
+
+func (pc *PostController) PublishPost(c *gin.Context) {
+	pc.changeStatus(c, models.PostStatusPublished, "Post published successfully")
+}
+
+func (pc *PostController) ArchivePost(c *gin.Context) {
+	pc.changeStatus(c, models.PostStatusArchived, "Post archived successfully")
+}
+
+func (pc *PostController) changeStatus(c *gin.Context, status, message string) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
 		return
 	}
 
-	if err := database.DB.Delete(&post).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete post", err)
+	post, err := pc.svc.ChangeStatus(c.Request.Context(), middleware.CurrentUser(c), id, status)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update post"))
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Post deleted successfully", nil)
+	utils.SuccessResponse(c, http.StatusOK, message, post)
 }
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type ProfileController struct {
+	svc *service.ProfileService
+}
+
+func NewProfileController(svc *service.ProfileService) *ProfileController {
+	return &ProfileController{svc: svc}
+}
+
+func (pc *ProfileController) GetMe(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "Profile fetched successfully", middleware.CurrentUser(c))
+}
+
+func (pc *ProfileController) UpdateMe(c *gin.Context) {
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	user, err := pc.svc.Update(c.Request.Context(), middleware.CurrentUser(c), &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update profile"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Profile updated successfully", user)
+}
+
+// ChangePassword 修改密码，当前会话保持登录，其他设备上的会话全部失效。
+func (pc *ProfileController) ChangePassword(c *gin.Context) {
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	if err := pc.svc.ChangePassword(c.Request.Context(), middleware.CurrentUser(c), c.GetString("tokenID"), &req); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to change password"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password changed successfully", nil)
+}
+
+func (pc *ProfileController) Deactivate(c *gin.Context) {
+	var req models.ConfirmPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	if err := pc.svc.Deactivate(c.Request.Context(), middleware.CurrentUser(c), req.Password); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to deactivate account"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Account deactivated", nil)
+}
+
+func (pc *ProfileController) DeleteMe(c *gin.Context) {
+	var req models.ConfirmPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	if err := pc.svc.Delete(c.Request.Context(), middleware.CurrentUser(c), req.Password); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to delete account"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Account deleted", nil)
+}
+
+// GetUserPage 返回用户公开资料和已发布的文章（分页参数与文章列表相同）。
+func (pc *ProfileController) GetUserPage(c *gin.Context) {
+	params, err := utils.ParsePageParams(c, postSortFields...)
+	if err != nil {
+		c.Error(apperror.BadRequest(err.Error()))
+		return
+	}
+
+	page, err := pc.svc.Page(c.Request.Context(), c.Param("username"), params)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch user"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User fetched successfully", page)
+}
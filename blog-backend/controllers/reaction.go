@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type ReactionController struct {
+	svc *service.ReactionService
+}
+
+func NewReactionController(svc *service.ReactionService) *ReactionController {
+	return &ReactionController{svc: svc}
+}
+
+// AddReaction 为文章添加表态，请求体为 {"type":"like"}。
+func (rc *ReactionController) AddReaction(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return
+	}
+	var req models.ReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	summary, err := rc.svc.Add(c.Request.Context(), middleware.CurrentUser(c), id, req.Type)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to add reaction"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Reaction added successfully", summary)
+}
+
+// RemoveReaction 撤销表态，类型通过查询参数传入，例如 ?type=like。
+func (rc *ReactionController) RemoveReaction(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return
+	}
+	var req models.ReactionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	summary, err := rc.svc.Remove(c.Request.Context(), middleware.CurrentUser(c), id, req.Type)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to remove reaction"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reaction removed successfully", summary)
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type ReadingListController struct {
+	svc *service.ReadingListService
+}
+
+func NewReadingListController(svc *service.ReadingListService) *ReadingListController {
+	return &ReadingListController{svc: svc}
+}
+
+// GetMyLists 返回当前用户的所有列表，不包含文章，item_count 为列表中的文章数。
+func (lc *ReadingListController) GetMyLists(c *gin.Context) {
+	lists, err := lc.svc.List(c.Request.Context(), middleware.CurrentUser(c))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch reading lists"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reading lists fetched successfully", lists)
+}
+
+func (lc *ReadingListController) CreateList(c *gin.Context) {
+	var req models.CreateReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	list, err := lc.svc.Create(c.Request.Context(), middleware.CurrentUser(c), &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to create reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Reading list created successfully", list)
+}
+
+func (lc *ReadingListController) GetList(c *gin.Context) {
+	id, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	list, err := lc.svc.Get(c.Request.Context(), middleware.CurrentUser(c), id)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reading list fetched successfully", list)
+}
+
+// GetPublicList 按 slug 返回公开的列表，无需登录。
+func (lc *ReadingListController) GetPublicList(c *gin.Context) {
+	list, err := lc.svc.GetPublic(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reading list fetched successfully", list)
+}
+
+func (lc *ReadingListController) UpdateList(c *gin.Context) {
+	id, ok := parseListID(c)
+	if !ok {
+		return
+	}
+	var req models.UpdateReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	list, err := lc.svc.Update(c.Request.Context(), middleware.CurrentUser(c), id, &req)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reading list updated successfully", list)
+}
+
+func (lc *ReadingListController) DeleteList(c *gin.Context) {
+	id, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	if err := lc.svc.Delete(c.Request.Context(), middleware.CurrentUser(c), id); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to delete reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reading list deleted successfully", nil)
+}
+
+func (lc *ReadingListController) AddItem(c *gin.Context) {
+	id, ok := parseListID(c)
+	if !ok {
+		return
+	}
+	var req models.AddReadingListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	list, err := lc.svc.AddItem(c.Request.Context(), middleware.CurrentUser(c), id, req.PostID)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to add post to reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Post added to reading list successfully", list)
+}
+
+func (lc *ReadingListController) RemoveItem(c *gin.Context) {
+	id, ok := parseListID(c)
+	if !ok {
+		return
+	}
+	postID, ok := parseID(c, "postId")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid post ID"))
+		return
+	}
+
+	list, err := lc.svc.RemoveItem(c.Request.Context(), middleware.CurrentUser(c), id, postID)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to remove post from reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Post removed from reading list successfully", list)
+}
+
+// ReorderItems 调整列表中文章的顺序，请求体为 {"post_ids":[3,1,2]}。
+func (lc *ReadingListController) ReorderItems(c *gin.Context) {
+	id, ok := parseListID(c)
+	if !ok {
+		return
+	}
+	var req models.ReorderReadingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	list, err := lc.svc.Reorder(c.Request.Context(), middleware.CurrentUser(c), id, req.PostIDs)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to reorder reading list"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reading list reordered successfully", list)
+}
+
+// parseListID 解析路由中的列表 ID，出错时已写入响应。
+func parseListID(c *gin.Context) (uint, bool) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid reading list ID"))
+	}
+	return id, ok
+}
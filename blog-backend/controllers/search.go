@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/search"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+const searchMaxLimit = 50
+
+type SearchController struct {
+	svc *service.SearchService
+}
+
+func NewSearchController(svc *service.SearchService) *SearchController {
+	return &SearchController{svc: svc}
+}
+
+// Search 按相关度搜索已发布文章，comments=true 时同时匹配评论内容。
+func (sc *SearchController) Search(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.Error(apperror.BadRequest("Search query is required"))
+		return
+	}
+
+	params, err := utils.ParsePageParams(c)
+	if err != nil || params.Cursor != nil {
+		c.Error(apperror.BadRequest("Invalid pagination parameters"))
+		return
+	}
+	page, limit := params.Page, params.Limit
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+	includeComments, _ := strconv.ParseBool(c.DefaultQuery("comments", "false"))
+
+	results, total, err := sc.svc.Search(c.Request.Context(), search.Query{
+		Text:            q,
+		IncludeComments: includeComments,
+		Offset:          (page - 1) * limit,
+		Limit:           limit,
+	})
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to search posts"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Search completed successfully", &utils.PageResult[service.SearchResult]{
+		Items:   results,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+		HasNext: int64(page*limit) < total,
+	})
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type TagController struct {
+	svc *service.PostService
+}
+
+func NewTagController(svc *service.PostService) *TagController {
+	return &TagController{svc: svc}
+}
+
+// GetTags 返回所有标签以及每个标签下已发布文章的数量。
+func (tc *TagController) GetTags(c *gin.Context) {
+	tags, err := tc.svc.ListTags(c.Request.Context())
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch tags"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tags fetched successfully", tags)
+}
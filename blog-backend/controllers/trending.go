@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"github.com/task/go_learn_task/blog-backend/views"
+)
+
+const (
+	trendingDefaultLimit = 10
+	trendingMaxLimit     = 50
+)
+
+type TrendingController struct {
+	svc *service.TrendingService
+}
+
+func NewTrendingController(svc *service.TrendingService) *TrendingController {
+	return &TrendingController{svc: svc}
+}
+
+// GetTrending 返回近期最热门的文章，window 为统计窗口（默认 24h，1h 到 168h），limit 默认 10，最多 50。
+func (tc *TrendingController) GetTrending(c *gin.Context) {
+	window, err := time.ParseDuration(c.DefaultQuery("window", "24h"))
+	if err != nil || window < time.Hour || window > views.MaxWindow {
+		c.Error(apperror.Invalid("window", "range", "must be a duration between 1h and 168h"))
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(trendingDefaultLimit)))
+	if err != nil || limit < 1 || limit > trendingMaxLimit {
+		c.Error(apperror.Invalid("limit", "range", "must be between 1 and 50"))
+		return
+	}
+
+	posts, err := tc.svc.Trending(c.Request.Context(), window, limit)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch trending posts"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Trending posts fetched successfully", posts)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type UserController struct {
+	svc *service.UserService
+}
+
+func NewUserController(svc *service.UserService) *UserController {
+	return &UserController{svc: svc}
+}
+
+func (uc *UserController) ListUsers(c *gin.Context) {
+	users, err := uc.svc.List(c.Request.Context(), c.Query("role"))
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to fetch users"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Users fetched successfully", users)
+}
+
+func (uc *UserController) UpdateUserRole(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid user ID"))
+		return
+	}
+
+	var req models.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	user, err := uc.svc.UpdateRole(c.Request.Context(), middleware.CurrentUser(c), id, req.Role)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update user role"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User role updated successfully", user)
+}
+
+// UpdateUserStatus 启用或停用用户。
+func (uc *UserController) UpdateUserStatus(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid user ID"))
+		return
+	}
+
+	var req models.UpdateUserStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Bind(err))
+		return
+	}
+
+	user, err := uc.svc.SetActive(c.Request.Context(), middleware.CurrentUser(c), id, *req.IsActive)
+	if err != nil {
+		c.Error(apperror.Wrap(err, "Failed to update user status"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User status updated successfully", user)
+}
+
+func (uc *UserController) DeleteUser(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		c.Error(apperror.BadRequest("Invalid user ID"))
+		return
+	}
+
+	if err := uc.svc.Delete(c.Request.Context(), middleware.CurrentUser(c), id); err != nil {
+		c.Error(apperror.Wrap(err, "Failed to delete user"))
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User deleted successfully", nil)
+}
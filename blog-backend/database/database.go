@@ -2,55 +2,103 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"github.com/task/go_learn_task/blog-backend/config"
-	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/logging"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
 
-func ConnectDB(cfg *config.Config) error {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
+func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
+	dialector, err := NewDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logging.NewGormLogger(slog.Default(), cfg.SlowQueryThreshold),
+		// 把各方言的唯一键冲突等错误转换为 gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	sqlDB, err := DB.DB()
+	sqlDB, err := db.DB()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	sqlDB.SetMaxIdleConns(10)
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	log.Println("✅ Database connected successfully")
-	return nil
+	// SQLite 内存数据库每个连接都是独立的库，只能使用单个连接
+	if cfg.DBDriver == DriverSQLite && isSQLiteMemory(cfg.DBPath) {
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+	}
+
+	slog.Info("database connected", "driver", cfg.DBDriver)
+	return db, nil
+}
+
+// NewDialector 根据 DB_DRIVER 构造对应方言的 DSN 和 GORM 驱动。
+func NewDialector(cfg *config.Config) (gorm.Dialector, error) {
+	switch cfg.DBDriver {
+	case DriverMySQL, "":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+			cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode)
+		return postgres.Open(dsn), nil
+	case DriverSQLite:
+		return sqlite.Open(sqliteDSN(cfg.DBPath)), nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", cfg.DBDriver)
+	}
+}
+
+func isSQLiteMemory(path string) bool {
+	return path == "" || path == ":memory:"
+}
+
+func sqliteDSN(path string) string {
+	if isSQLiteMemory(path) {
+		return "file::memory:?_pragma=foreign_keys(1)"
+	}
+	return fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", path)
 }
 
-func MigrateDB() error {
-	err := DB.AutoMigrate(
-		&models.User{},
-		&models.Post{},
-		&models.Comment{},
-	)
+// CloseDB 关闭连接池，等待正在执行的查询结束。
+func CloseDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
 
+// MigrateDB 应用所有未执行的版本化迁移。
+func MigrateDB(db *gorm.DB) error {
+	applied, err := MigrateUp(db)
 	if err != nil {
 		return err
 	}
 
-	log.Println("✅ Database migrated successfully")
+	slog.Info("database migrated", "applied", applied)
 	return nil
 }
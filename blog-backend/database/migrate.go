@@ -0,0 +1,334 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+const (
+	migrationLockName    = "blog_schema_migrations"
+	migrationLockTimeout = 30 * time.Second
+	// migrationLockStale 之后认为 SQLite 锁表中的记录来自已崩溃的进程
+	migrationLockStale = 15 * time.Minute
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var ErrMigrationLocked = errors.New("another instance is running migrations")
+
+// Migration 是一个带编号的迁移，Up/Down 为对应方言的 SQL。
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// LoadMigrations 读取内嵌的 migrations/<dialect>/NNNN_name.{up,down}.sql，按版本号排序返回。
+func LoadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %q: %w", dialect, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// MigrateUp 执行所有未应用的迁移，返回本次应用的数量。
+func MigrateUp(db *gorm.DB) (int, error) {
+	migrations, err := LoadMigrations(db.Dialector.Name())
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = withMigrationLock(db, func() error {
+		applied, err := appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			if err := runMigration(db, m, m.Up, true); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", m.Version, m.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// MigrateDown 按版本从高到低回滚最近 steps 个已应用的迁移，返回回滚的数量。
+func MigrateDown(db *gorm.DB, steps int) (int, error) {
+	migrations, err := LoadMigrations(db.Dialector.Name())
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = withMigrationLock(db, func() error {
+		applied, err := appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+		for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			if err := runMigration(db, m, m.Down, false); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", m.Version, m.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// MigrationStatuses 返回所有已知迁移及其应用时间。
+func MigrationStatuses(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int64]time.Time, error) {
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// runMigration 在一个事务中执行迁移语句并更新 schema_migrations。
+// MySQL 的 DDL 会隐式提交，失败时可能需要手动清理。
+func runMigration(db *gorm.DB, m Migration, script string, up bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range splitStatements(script) {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		if up {
+			return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		}
+		return tx.Delete(&schemaMigration{}, m.Version).Error
+	})
+}
+
+// splitStatements 按行尾分号切分 SQL 脚本，忽略 -- 注释行。迁移文件中不应在字符串里换行写分号。
+func splitStatements(script string) []string {
+	var stmts []string
+	var b strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(b.String()), ";"))
+			b.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(b.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
+
+// withMigrationLock 保证同一时间只有一个实例执行迁移：MySQL 使用 GET_LOCK，
+// PostgreSQL 使用 advisory lock，SQLite 使用锁表。
+func withMigrationLock(db *gorm.DB, fn func() error) error {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrationLockTimeout)
+	defer cancel()
+
+	var unlock func() error
+	var err error
+	switch db.Dialector.Name() {
+	case DriverMySQL:
+		unlock, err = sessionLock(ctx, db, "SELECT GET_LOCK(?, ?)", "SELECT RELEASE_LOCK(?)",
+			[]interface{}{migrationLockName, int(migrationLockTimeout.Seconds())}, []interface{}{migrationLockName})
+	case DriverPostgres:
+		key := int64(0x626c6f67) // "blog"
+		unlock, err = sessionLock(ctx, db, "SELECT pg_try_advisory_lock($1)::int", "SELECT pg_advisory_unlock($1)::int",
+			[]interface{}{key}, []interface{}{key})
+	default:
+		unlock, err = tableLock(ctx, db)
+	}
+	if err != nil {
+		return err
+	}
+
+	fnErr := fn()
+	if err := unlock(); err != nil && fnErr == nil {
+		fnErr = err
+	}
+	return fnErr
+}
+
+// sessionLock 在专用连接上获取会话级锁，获取失败时重试直到 ctx 超时。连接断开时数据库会自动释放锁。
+func sessionLock(ctx context.Context, db *gorm.DB, lockSQL, unlockSQL string, lockArgs, unlockArgs []interface{}) (func() error, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var got sql.NullInt64
+		if err := conn.QueryRowContext(ctx, lockSQL, lockArgs...).Scan(&got); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if got.Valid && got.Int64 == 1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return nil, ErrMigrationLocked
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), unlockSQL, unlockArgs...)
+		return err
+	}, nil
+}
+
+type schemaMigrationLock struct {
+	ID       int       `gorm:"primaryKey;autoIncrement:false"`
+	LockedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigrationLock) TableName() string {
+	return "schema_migrations_lock"
+}
+
+// tableLock 通过插入唯一记录加锁，等待到 ctx 超时为止；超过 migrationLockStale 的旧锁会被清除。
+func tableLock(ctx context.Context, db *gorm.DB) (func() error, error) {
+	if err := db.AutoMigrate(&schemaMigrationLock{}); err != nil {
+		return nil, err
+	}
+
+	for {
+		db.Where("id = 1 AND locked_at < ?", time.Now().Add(-migrationLockStale)).Delete(&schemaMigrationLock{})
+		if err := db.Create(&schemaMigrationLock{ID: 1, LockedAt: time.Now()}).Error; err == nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ErrMigrationLocked
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	return func() error {
+		return db.Delete(&schemaMigrationLock{}, 1).Error
+	}, nil
+}
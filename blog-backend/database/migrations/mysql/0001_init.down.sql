@@ -0,0 +1,9 @@
+ALTER TABLE comments DROP INDEX idx_comments_fulltext;
+ALTER TABLE posts DROP INDEX idx_posts_fulltext;
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS posts;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN email_verified_at DATETIME(3) NULL;
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    purpose VARCHAR(32) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at DATETIME(3) NOT NULL,
+    used_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE KEY idx_user_tokens_token_hash (token_hash),
+    KEY idx_user_tokens_user_id (user_id),
+    CONSTRAINT fk_user_tokens_user FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
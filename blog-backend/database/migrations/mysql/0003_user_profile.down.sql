@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN is_active,
+    DROP COLUMN bio,
+    DROP COLUMN avatar,
+    DROP COLUMN nickname;
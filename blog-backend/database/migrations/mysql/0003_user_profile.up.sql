@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN nickname VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN avatar VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN bio VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
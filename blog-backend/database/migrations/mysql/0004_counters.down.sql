@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN post_count;
+ALTER TABLE posts
+    DROP COLUMN view_count,
+    DROP COLUMN comment_count;
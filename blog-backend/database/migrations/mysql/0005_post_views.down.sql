@@ -0,0 +1,2 @@
+ALTER TABLE comments DROP KEY idx_comments_created_at;
+DROP TABLE IF EXISTS post_view_stats;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS post_view_stats (
+    post_id BIGINT UNSIGNED NOT NULL,
+    bucket DATETIME(3) NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, bucket),
+    KEY idx_post_view_stats_bucket (bucket),
+    CONSTRAINT fk_post_view_stats_post FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+ALTER TABLE comments ADD KEY idx_comments_created_at (created_at);
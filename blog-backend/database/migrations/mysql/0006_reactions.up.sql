@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS reactions (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    post_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE KEY idx_reactions_unique (post_id, user_id, type),
+    KEY idx_reactions_user_id (user_id),
+    CONSTRAINT fk_reactions_post FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_reactions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
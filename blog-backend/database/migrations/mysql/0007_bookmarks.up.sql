@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE KEY idx_bookmarks_user_post (user_id, post_id),
+    KEY idx_bookmarks_post_id (post_id),
+    CONSTRAINT fk_bookmarks_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_bookmarks_post FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    UNIQUE KEY idx_reading_lists_slug (slug),
+    KEY idx_reading_lists_user_id (user_id),
+    CONSTRAINT fk_reading_lists_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS reading_list_items (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    list_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NOT NULL,
+    position INT NOT NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE KEY idx_reading_list_items_list_post (list_id, post_id),
+    KEY idx_reading_list_items_post_id (post_id),
+    CONSTRAINT fk_reading_list_items_list FOREIGN KEY (list_id) REFERENCES reading_lists (id) ON DELETE CASCADE,
+    CONSTRAINT fk_reading_list_items_post FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,5 @@
+ALTER TABLE posts DROP KEY idx_posts_user_created_at;
+ALTER TABLE users
+    DROP COLUMN following_count,
+    DROP COLUMN follower_count;
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS follows (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    follower_id BIGINT UNSIGNED NOT NULL,
+    followee_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE KEY idx_follows_pair (follower_id, followee_id),
+    KEY idx_follows_followee_id (followee_id),
+    CONSTRAINT fk_follows_follower FOREIGN KEY (follower_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_follows_followee FOREIGN KEY (followee_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+ALTER TABLE users
+    ADD COLUMN follower_count BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN following_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD KEY idx_posts_user_created_at (user_id, created_at);
@@ -0,0 +1 @@
+-- 改写前的用户名和邮箱无法恢复，回滚不做修改
@@ -0,0 +1 @@
+UPDATE users SET username = CONCAT('deleted-', id), email = CONCAT('deleted-', id) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS post_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS view_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
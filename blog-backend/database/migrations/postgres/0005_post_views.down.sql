@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_comments_created_at;
+DROP TABLE IF EXISTS post_view_stats;
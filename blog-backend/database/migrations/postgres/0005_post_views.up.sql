@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS post_view_stats (
+    post_id BIGINT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    bucket TIMESTAMPTZ NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, bucket)
+);
+CREATE INDEX IF NOT EXISTS idx_post_view_stats_bucket ON post_view_stats (bucket);
+CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments (created_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_posts_user_created_at;
+ALTER TABLE users DROP COLUMN IF EXISTS following_count;
+ALTER TABLE users DROP COLUMN IF EXISTS follower_count;
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS follows (
+    id BIGSERIAL PRIMARY KEY,
+    follower_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followee_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follows_pair ON follows (follower_id, followee_id);
+CREATE INDEX IF NOT EXISTS idx_follows_followee_id ON follows (followee_id);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS follower_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS following_count BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_posts_user_created_at ON posts (user_id, created_at);
@@ -0,0 +1 @@
+UPDATE users SET username = 'deleted-' || id, email = 'deleted-' || id WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN is_active;
+ALTER TABLE users DROP COLUMN bio;
+ALTER TABLE users DROP COLUMN avatar;
+ALTER TABLE users DROP COLUMN nickname;
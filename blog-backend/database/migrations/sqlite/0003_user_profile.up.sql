@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN nickname VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN avatar VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN bio VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN post_count;
+ALTER TABLE posts DROP COLUMN view_count;
+ALTER TABLE posts DROP COLUMN comment_count;
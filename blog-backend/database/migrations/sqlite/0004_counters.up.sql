@@ -0,0 +1,9 @@
+ALTER TABLE posts ADD COLUMN comment_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN view_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN post_count INTEGER NOT NULL DEFAULT 0;
+UPDATE posts SET comment_count = (
+    SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL
+);
+UPDATE users SET post_count = (
+    SELECT COUNT(*) FROM posts WHERE posts.user_id = users.id AND posts.deleted_at IS NULL
+);
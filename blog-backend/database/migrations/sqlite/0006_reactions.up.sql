@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS reactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    post_id INTEGER NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    created_at DATETIME NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reactions_unique ON reactions (post_id, user_id, type);
+CREATE INDEX IF NOT EXISTS idx_reactions_user_id ON reactions (user_id);
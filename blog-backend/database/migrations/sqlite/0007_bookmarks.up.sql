@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    post_id INTEGER NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    created_at DATETIME NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmarks_user_post ON bookmarks (user_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_post_id ON bookmarks (post_id);
+
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    is_public BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NULL,
+    updated_at DATETIME NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reading_lists_slug ON reading_lists (slug);
+CREATE INDEX IF NOT EXISTS idx_reading_lists_user_id ON reading_lists (user_id);
+
+CREATE TABLE IF NOT EXISTS reading_list_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    list_id INTEGER NOT NULL REFERENCES reading_lists (id) ON DELETE CASCADE,
+    post_id INTEGER NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    created_at DATETIME NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reading_list_items_list_post ON reading_list_items (list_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_reading_list_items_post_id ON reading_list_items (post_id);
@@ -0,0 +1,90 @@
+// Package feed 缓存每个用户关注的作者，供关注动态使用。
+// 动态在读取时按关注列表查询文章（fan-out-on-read），发文时不需要写入每个粉丝的收件箱；
+// 翻页时关注列表从缓存读取，只需一次按作者和时间过滤的文章查询。
+// 缓存保存在进程内存中，关注和取消关注时失效，多实例部署时其他实例最多在 TTL 后看到变化。
+package feed
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	userID    uint
+	ids       []uint
+	expiresAt time.Time
+}
+
+// Cache 是按用户保存关注列表的 LRU 缓存，条目在 ttl 后过期，超过 size 个用户时淘汰最久未使用的条目。
+type Cache struct {
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[uint]*list.Element
+	// order 按最近使用排序，最前面的最新
+	order *list.List
+}
+
+func NewCache(ttl time.Duration, size int) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		size:    size,
+		now:     time.Now,
+		entries: make(map[uint]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get 返回用户的关注列表，不存在或已过期时返回 false。返回的切片不能修改。
+func (c *Cache) Get(userID uint) ([]uint, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[userID]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.ids, true
+}
+
+func (c *Cache) Set(userID uint, ids []uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if el, ok := c.entries[userID]; ok {
+		e := el.Value.(*entry)
+		e.ids, e.expiresAt = ids, expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[userID] = c.order.PushFront(&entry{userID: userID, ids: ids, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Invalidate 删除用户的关注列表，在关注或取消关注后调用。
+func (c *Cache) Invalidate(userID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[userID]; ok {
+		c.remove(el)
+	}
+}
+
+// remove 删除条目，调用方需持有锁。
+func (c *Cache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*entry).userID)
+}
@@ -0,0 +1,74 @@
+package feed
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestCacheExpiresAndInvalidates(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+	cache := NewCache(time.Minute, 10)
+	cache.now = clock.Now
+
+	if _, ok := cache.Get(1); ok {
+		t.Fatal("Get() on empty cache hit")
+	}
+	cache.Set(1, []uint{2, 3})
+	if ids, ok := cache.Get(1); !ok || !slices.Equal(ids, []uint{2, 3}) {
+		t.Fatalf("Get() = %v, %v; want [2 3]", ids, ok)
+	}
+
+	// 读取不会延长有效期
+	clock.Advance(59 * time.Second)
+	cache.Get(1)
+	clock.Advance(time.Second)
+	if _, ok := cache.Get(1); ok {
+		t.Fatal("expired entry returned")
+	}
+	if cache.order.Len() != 0 {
+		t.Errorf("expired entry not removed, len = %d", cache.order.Len())
+	}
+
+	cache.Set(1, []uint{2})
+	cache.Invalidate(1)
+	if _, ok := cache.Get(1); ok {
+		t.Fatal("invalidated entry returned")
+	}
+	cache.Invalidate(1)
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(time.Hour, 2)
+
+	cache.Set(1, []uint{10})
+	cache.Set(2, []uint{20})
+	cache.Get(1)
+	cache.Set(3, []uint{30})
+
+	if _, ok := cache.Get(2); ok {
+		t.Error("least recently used entry not evicted")
+	}
+	for _, id := range []uint{1, 3} {
+		if _, ok := cache.Get(id); !ok {
+			t.Errorf("entry %d evicted", id)
+		}
+	}
+
+	// 更新已有条目不会淘汰其他条目
+	cache.Set(1, []uint{11})
+	if ids, ok := cache.Get(1); !ok || !slices.Equal(ids, []uint{11}) {
+		t.Errorf("Get(1) = %v, %v; want [11]", ids, ok)
+	}
+	if _, ok := cache.Get(3); !ok {
+		t.Error("entry 3 evicted by update")
+	}
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormLogger 把 GORM 的日志写入 slog：SQL 语句为 debug 级别，超过阈值的慢查询为 warn，
+// 执行出错为 error（记录不存在除外）。请求 ID 通过 db.WithContext 传入的 context 获得。
+type GormLogger struct {
+	logger        *slog.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+var _ logger.Interface = (*GormLogger)(nil)
+
+// NewGormLogger 创建 GORM 日志适配器，slowThreshold 为 0 时不记录慢查询。
+func NewGormLogger(l *slog.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{logger: l, level: logger.Info, slowThreshold: slowThreshold}
+}
+
+func (g *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *g
+	clone.level = level
+	return &clone
+}
+
+func (g *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= logger.Info {
+		g.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= logger.Warn {
+		g.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if g.level >= logger.Error {
+		g.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (g *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	level := slog.LevelDebug
+	msg := "sql"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && g.level >= logger.Error:
+		level, msg = slog.LevelError, "sql error"
+	case g.slowThreshold > 0 && elapsed > g.slowThreshold && g.level >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow query"
+	}
+	if !g.logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	g.logger.LogAttrs(ctx, level, msg, attrs...)
+}
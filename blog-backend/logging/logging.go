@@ -0,0 +1,79 @@
+// Package logging 基于 log/slog 提供结构化日志：可配置级别和格式，并自动附带请求 ID。
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID 返回携带请求 ID 的 context，使用该 context 记录的日志都会带上 request_id 字段。
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID 返回 context 中的请求 ID，不存在时返回空字符串。
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ParseLevel 解析 debug、info、warn、error，无法识别时返回 info。
+func ParseLevel(s string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// New 创建写入 w 的 logger，format 为 text 时输出 key=value 格式，其余情况输出 JSON。
+func New(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(format, FormatText) {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// Setup 创建 logger 并设为默认 logger，标准库 log 包的输出也会经过它。
+func Setup(w io.Writer, level, format string) *slog.Logger {
+	logger := New(w, level, format)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// contextHandler 从 context 中取出请求 ID 追加到每条日志。
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
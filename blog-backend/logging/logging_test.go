@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "info", FormatJSON)
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-1"), "hello")
+	logger.Info("no context")
+	logger.DebugContext(context.Background(), "filtered")
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %s", len(lines), buf.String())
+	}
+	if lines[0]["request_id"] != "req-1" {
+		t.Errorf("request_id = %v, want req-1", lines[0]["request_id"])
+	}
+	if _, ok := lines[1]["request_id"]; ok {
+		t.Errorf("unexpected request_id in %v", lines[1])
+	}
+}
+
+func TestGormLoggerTrace(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-2")
+	fc := func() (string, int64) { return "SELECT 1", 1 }
+
+	tests := []struct {
+		name    string
+		level   string
+		elapsed time.Duration
+		err     error
+		want    string
+	}{
+		{"statement at debug", "debug", 0, nil, "sql"},
+		{"statement hidden at info", "info", 0, nil, ""},
+		{"slow query", "info", time.Second, nil, "slow query"},
+		{"error", "info", 0, errors.New("boom"), "sql error"},
+		{"record not found is not an error", "info", 0, gorm.ErrRecordNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			g := NewGormLogger(New(&buf, tt.level, FormatJSON), 100*time.Millisecond)
+			g.Trace(ctx, time.Now().Add(-tt.elapsed), fc, tt.err)
+
+			lines := decodeLines(t, &buf)
+			if tt.want == "" {
+				if len(lines) != 0 {
+					t.Fatalf("unexpected log: %s", buf.String())
+				}
+				return
+			}
+			if len(lines) != 1 || lines[0]["msg"] != tt.want {
+				t.Fatalf("got %s, want msg %q", buf.String(), tt.want)
+			}
+			if lines[0]["request_id"] != "req-2" || lines[0]["sql"] != "SELECT 1" {
+				t.Errorf("missing fields in %v", lines[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package mailer 发送系统邮件。Mailer 接口有三种实现：SMTP、写入本地 outbox 目录的文件实现，
+// 以及只输出日志的实现，后两者用于本地开发和测试。
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// Message 是一封纯文本邮件。
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New 按 MAIL_DRIVER 创建 Mailer。
+func New(cfg *config.Config) (Mailer, error) {
+	from, err := mail.ParseAddress(cfg.MailFrom)
+	if err != nil {
+		return nil, fmt.Errorf("MAIL_FROM: %w", err)
+	}
+
+	switch cfg.MailDriver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, from), nil
+	case "file":
+		return NewFileMailer(cfg.MailOutboxDir, from)
+	case "log":
+		return NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver %q", cfg.MailDriver)
+	}
+}
+
+// render 把邮件编码为 RFC 5322 格式，主题使用 MIME 编码以支持非 ASCII 字符。
+func render(from *mail.Address, msg Message, now time.Time) ([]byte, error) {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %w", msg.To, err)
+	}
+	id, err := utils.GenerateRandomToken(12)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", id, domainOf(from.Address))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.Write(bytes.ReplaceAll([]byte(msg.Body), []byte("\n"), []byte("\r\n")))
+	return buf.Bytes(), nil
+}
+
+func domainOf(address string) string {
+	for i := len(address) - 1; i >= 0; i-- {
+		if address[i] == '@' {
+			return address[i+1:]
+		}
+	}
+	return "localhost"
+}
@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// FileMailer 把每封邮件写成 outbox 目录下的一个 .eml 文件，可以直接用邮件客户端打开。
+type FileMailer struct {
+	dir  string
+	from *mail.Address
+}
+
+func NewFileMailer(dir string, from *mail.Address) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create mail outbox: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := render(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	suffix, err := utils.GenerateRandomToken(6)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(m.dir, now.UTC().Format("20060102T150405.000000000")+"-"+suffix+".eml")
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "email written to outbox", "to", msg.To, "subject", msg.Subject, "file", name)
+	return nil
+}
+
+// LogMailer 不发送邮件，只把内容输出到日志。邮件中包含一次性令牌，不应在生产环境使用。
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (LogMailer) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(ctx, "email", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
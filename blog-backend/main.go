@@ -1,75 +1,138 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
-	"github.com/gin-gonic/gin"
 	"github.com/task/go_learn_task/blog-backend/config"
-	"github.com/task/go_learn_task/blog-backend/controllers"
 	"github.com/task/go_learn_task/blog-backend/database"
-	"github.com/task/go_learn_task/blog-backend/middleware"
-	"github.com/task/go_learn_task/blog-backend/utils"
+	"github.com/task/go_learn_task/blog-backend/logging"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/scheduler"
+	"github.com/task/go_learn_task/blog-backend/views"
 )
 
 func main() {
 	// 加载配置
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fatal("failed to load configuration", err)
+	}
+
+	// 初始化日志
+	logging.Setup(os.Stderr, cfg.LogLevel, cfg.LogFormat)
+	slog.Info("configuration loaded", "config", cfg)
+	for _, warning := range cfg.Warnings() {
+		slog.Warn(warning)
+	}
+
+	// 数据库迁移子命令
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(cfg, os.Args[2:])
+		return
+	}
+
+	// 冗余计数核对子命令
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcileCommand(cfg, os.Args[2:])
+		return
+	}
 
 	// 连接数据库
-	if err := database.ConnectDB(cfg); err != nil {
-		log.Fatalf("❌ Failed to connect to database: %v", err)
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		fatal("failed to connect to database", err)
 	}
 
 	// 数据库迁移
-	if err := database.MigrateDB(); err != nil {
-		log.Fatalf("❌ Failed to migrate database: %v", err)
+	if cfg.AutoMigrate {
+		if err := database.MigrateDB(db); err != nil {
+			fatal("failed to migrate database", err)
+		}
 	}
 
-	// 设置Gin模式
-	gin.SetMode(gin.ReleaseMode)
-	router := gin.New()
-
-	// 中间件
-	router.Use(middleware.LoggerMiddleware())
-	router.Use(gin.Recovery())
-
-	// 初始化控制器
-	authController := controllers.NewAuthController(cfg)
-	postController := controllers.NewPostController()
-	commentController := controllers.NewCommentController()
-
-	// 公开路由
-	router.POST("/api/register", authController.Register)
-	router.POST("/api/login", authController.Login)
-
-	// 文章公开路由
-	router.GET("/api/posts", postController.GetAllPosts)
-	router.GET("/api/posts/:id", postController.GetPost)
-
-	// 认证路由组
-	auth := router.Group("/api")
-	auth.Use(middleware.AuthMiddleware(cfg))
-	{
-		// 需要认证的文章操作
-		auth.POST("/posts", postController.CreatePost)
-		auth.PUT("/posts/:id", postController.UpdatePost)
-		auth.DELETE("/posts/:id", postController.DeletePost)
-
-		// 评论操作
-		auth.POST("/post-comments/:postId/comments", commentController.CreateComment)
+	// 浏览量统计
+	tracker := views.NewTracker(repository.NewViewRepository(db), cfg.ViewDedupWindow)
+
+	// 初始化路由
+	router, err := NewRouter(cfg, db, WithViewTracker(tracker))
+	if err != nil {
+		fatal("failed to initialize router", err)
 	}
 
-	// 评论公开路由
-	router.GET("/api/post-comments/:postId/comments", commentController.GetPostComments)
+	// 收到 SIGINT/SIGTERM 时取消 ctx，开始优雅退出
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// 健康检查
-	router.GET("/health", func(c *gin.Context) {
-		utils.SuccessResponse(c, 200, "Server is running", nil)
-	})
+	// 后台任务
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		// 定时发布
+		scheduler.StartPublisher(ctx, repository.NewPostRepository(db), cfg.PublishInterval)
+	}()
+	go func() {
+		defer workers.Done()
+		// 批量写入浏览量，退出时写入剩余增量
+		tracker.Run(ctx, cfg.ViewFlushInterval)
+	}()
 
 	// 启动服务器
-	log.Printf("🚀 Server starting on port %s", cfg.ServerPort)
-	if err := router.Run(":" + cfg.ServerPort); err != nil {
-		log.Fatalf("❌ Failed to start server: %v", err)
+	srv := &http.Server{
+		Addr:              ":" + cfg.ServerPort,
+		Handler:           router,
+		ReadHeaderTimeout: cfg.ReadTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
 	}
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "port", cfg.ServerPort)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	// 服务器异常退出（例如端口被占用）时仍然完成清理，但以非 0 状态退出，让进程管理器识别为失败
+	failed := false
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("server stopped unexpectedly", "error", err)
+			failed = true
+		}
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining requests", "timeout", cfg.ShutdownTimeout.String())
+	}
+	stop()
+
+	// 停止接收新连接并等待处理中的请求完成
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("server shutdown incomplete", "error", err)
+	}
+
+	// 等待后台任务退出后再关闭数据库
+	workers.Wait()
+	if err := database.CloseDB(db); err != nil {
+		slog.Error("failed to close database", "error", err)
+	}
+	if failed {
+		cancel()
+		os.Exit(1)
+	}
+	slog.Info("server stopped")
+}
+
+// fatal 记录错误后退出进程。
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,112 @@
+// Package metrics 定义 Prometheus 指标：HTTP 请求、数据库连接池和业务事件。
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "blog"
+
+// Metrics 持有独立的 Registry，每个 NewRouter 各自注册，互不冲突。
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	registrations   prometheus.Counter
+	logins          *prometheus.CounterVec
+	postsCreated    prometheus.Counter
+	commentsCreated prometheus.Counter
+}
+
+// New 创建并注册所有指标，db 为 ConnectDB 配置的连接池，dbName 作为 db_name 标签。
+func New(db *sql.DB, dbName string) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_registrations_total",
+			Help:      "Users registered.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts by result (succeeded or failed).",
+		}, []string{"result"}),
+		postsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "posts_created_total",
+			Help:      "Posts created.",
+		}),
+		commentsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comments_created_total",
+			Help:      "Comments created.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, dbName),
+		m.httpRequests,
+		m.httpDuration,
+		m.registrations,
+		m.logins,
+		m.postsCreated,
+		m.commentsCreated,
+	)
+	// 预先创建标签组合，使计数从 0 开始出现在 /metrics 中
+	m.logins.WithLabelValues("succeeded")
+	m.logins.WithLabelValues("failed")
+	return m
+}
+
+// Handler 返回 /metrics 的处理器。
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest 记录一次 HTTP 请求，route 为路由模板（如 /api/posts/:id），避免按 ID 产生大量标签。
+func (m *Metrics) ObserveRequest(method, route string, status int, seconds float64) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(seconds)
+}
+
+func (m *Metrics) UserRegistered() {
+	m.registrations.Inc()
+}
+
+func (m *Metrics) LoginAttempted(success bool) {
+	result := "failed"
+	if success {
+		result = "succeeded"
+	}
+	m.logins.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) PostCreated() {
+	m.postsCreated.Inc()
+}
+
+func (m *Metrics) CommentCreated() {
+	m.commentsCreated.Inc()
+}
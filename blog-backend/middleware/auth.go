@@ -1,42 +1,37 @@
 package middleware
 
 import (
-	"strings"
-
 	"github.com/gin-gonic/gin"
-	"github.com/task/go_learn_task/blog-backend/config"
-	"github.com/task/go_learn_task/blog-backend/database"
-	"github.com/task/go_learn_task/blog-backend/models"
-	"github.com/task/go_learn_task/blog-backend/utils"
+	"github.com/task/go_learn_task/blog-backend/service"
 )
 
-func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+func AuthMiddleware(svc *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			utils.UnauthorizedResponse(c)
-			c.Abort()
-			return
-		}
-
-		tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-
-		claims, err := utils.ValidateToken(tokenString, cfg)
-		if err != nil {
-			utils.UnauthorizedResponse(c)
-			c.Abort()
-			return
-		}
-
-		var user models.User
-		if err := database.DB.First(&user, claims.UserID).Error; err != nil {
-			utils.ErrorResponse(c, 404, "User not found", err)
+		if err := authenticate(c, svc); err != nil {
+			c.Error(err)
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		c.Set("user", &user)
-		c.Set("userID", user.ID)
+// OptionalAuthMiddleware 用于公开路由：携带有效令牌时写入当前用户，否则按匿名用户继续处理。
+func OptionalAuthMiddleware(svc *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = authenticate(c, svc)
 		c.Next()
 	}
 }
+
+func authenticate(c *gin.Context, svc *service.AuthService) error {
+	user, claims, err := svc.Authenticate(c.Request.Context(), c.GetHeader("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	c.Set("user", user)
+	c.Set("userID", user.ID)
+	c.Set("tokenID", claims.ID)
+	return nil
+}
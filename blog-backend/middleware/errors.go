@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// ErrorMiddleware 统一渲染处理器通过 c.Error 上报的错误。5xx 错误只记录日志，对客户端返回通用提示。
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+		appErr := apperror.From(c.Errors.Last().Err)
+		if appErr.Status >= 500 {
+			slog.ErrorContext(c.Request.Context(), "request failed",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"error", appErr.Error(),
+			)
+		}
+		if c.Writer.Written() {
+			return
+		}
+
+		if appErr.RetryAfter > 0 {
+			// Retry-After 以秒为单位，向上取整避免客户端过早重试
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+		}
+
+		var details interface{}
+		if len(appErr.Details) > 0 {
+			details = appErr.Details
+		}
+		utils.ErrorResponse(c, appErr.Status, appErr.Code, appErr.Message, details)
+	}
+}
+
+// RecoveryMiddleware 把 panic 转换为内部错误交给 ErrorMiddleware 处理，必须注册在 ErrorMiddleware 之后。
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		c.Error(apperror.Internal(fmt.Errorf("panic: %v", recovered)))
+		c.Abort()
+	})
+}
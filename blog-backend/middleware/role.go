@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/policy"
+)
+
+// RequireRole 要求当前用户属于给定角色之一，必须放在 AuthMiddleware 之后。
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil || !user.HasRole(roles...) {
+			c.Error(apperror.Forbidden("Insufficient role"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission 要求当前用户的角色拥有指定权限，必须放在 AuthMiddleware 之后。
+func RequirePermission(perm policy.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.Can(CurrentUser(c), perm) {
+			c.Error(apperror.Forbidden("Permission denied"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail 要求当前用户已验证邮箱，必须放在 AuthMiddleware 之后。
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil || !user.EmailVerified() {
+			c.Error(apperror.Forbidden("Email address not verified"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// CurrentUser 返回 AuthMiddleware 写入上下文的用户，未认证时返回 nil。
+func CurrentUser(c *gin.Context) *models.User {
+	value, exists := c.Get("user")
+	if !exists {
+		return nil
+	}
+	user, _ := value.(*models.User)
+	return user
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/database"
+)
+
+const migrateUsage = `usage: blog-backend migrate <command>
+
+commands:
+  up        apply all pending migrations
+  down [N]  roll back the last N applied migrations (default 1)
+  status    list migrations and whether they are applied`
+
+// runMigrateCommand 处理 `blog-backend migrate ...` 子命令。
+func runMigrateCommand(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := database.MigrateUp(db)
+		if err != nil {
+			fatal("migration failed", err)
+		}
+		fmt.Printf("applied %d migration(s)\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "invalid number of steps %q\n", args[1])
+				os.Exit(2)
+			}
+			steps = n
+		}
+		reverted, err := database.MigrateDown(db, steps)
+		if err != nil {
+			fatal("rollback failed", err)
+		}
+		fmt.Printf("rolled back %d migration(s)\n", reverted)
+	case "status":
+		statuses, err := database.MigrationStatuses(db)
+		if err != nil {
+			fatal("failed to read migration status", err)
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-30s %s\n", s.Version, s.Name, applied)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Bookmark 是用户收藏的文章，每个用户对同一文章最多收藏一次。
+type Bookmark struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_post,priority:1" json:"user_id"`
+	PostID    uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_post,priority:2;index" json:"post_id"`
+	Post      Post      `gorm:"foreignKey:PostID" json:"post"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 阅读列表的数量上限，避免单个用户无限制地写入。
+const (
+	MaxReadingListsPerUser = 50
+	MaxReadingListItems    = 200
+)
+
+// ReadingList 是用户命名的文章列表。Slug 在创建时生成且不随改名变化，公开的列表可以通过它分享。
+// ItemCount 只在列表查询中由子查询填充，Owner 只在公开访问时填充。
+type ReadingList struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	UserID      uint              `gorm:"not null;index" json:"user_id"`
+	Owner       *PublicProfile    `gorm:"-" json:"owner,omitempty"`
+	Name        string            `gorm:"type:varchar(100);not null" json:"name"`
+	Slug        string            `gorm:"type:varchar(120);uniqueIndex;not null" json:"slug"`
+	Description string            `gorm:"type:varchar(500);not null;default:''" json:"description"`
+	IsPublic    bool              `gorm:"not null;default:false" json:"is_public"`
+	ItemCount   int64             `gorm:"->;-:migration" json:"item_count"`
+	Items       []ReadingListItem `gorm:"foreignKey:ListID" json:"items,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// ReadingListItem 是列表中的一篇文章，按 Position 升序排列。
+type ReadingListItem struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	ListID    uint      `gorm:"not null;uniqueIndex:idx_reading_list_items_list_post,priority:1" json:"list_id"`
+	PostID    uint      `gorm:"not null;uniqueIndex:idx_reading_list_items_list_post,priority:2;index" json:"post_id"`
+	Post      Post      `gorm:"foreignKey:PostID" json:"post"`
+	Position  int       `gorm:"not null" json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateReadingListRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// UpdateReadingListRequest 只修改提供的字段。
+type UpdateReadingListRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	IsPublic    *bool   `json:"is_public"`
+}
+
+type AddReadingListItemRequest struct {
+	PostID uint `json:"post_id" binding:"required"`
+}
+
+// ReorderReadingListRequest 给出文章的新顺序，未列出的文章保持原有顺序排在后面。
+type ReorderReadingListRequest struct {
+	PostIDs []uint `json:"post_ids" binding:"required,min=1,unique"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+type Category struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	Slug        string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"slug"`
+	Description string    `gorm:"type:varchar(500)" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateCategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Slug        string `json:"slug" binding:"omitempty,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        string `json:"name" binding:"omitempty,max=100"`
+	Slug        string `json:"slug" binding:"omitempty,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
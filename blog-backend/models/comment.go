@@ -6,18 +6,86 @@ import (
 	"gorm.io/gorm"
 )
 
+// DeletedCommentPlaceholder 替换已删除但仍有回复的评论内容。
+const DeletedCommentPlaceholder = "[deleted]"
+
 type Comment struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	Content   string         `gorm:"type:text;not null" json:"content"`
 	UserID    uint           `gorm:"not null" json:"user_id"`
 	User      User           `gorm:"foreignKey:UserID" json:"user"`
-	PostID    uint           `gorm:"not null" json:"post_id"`
+	PostID    uint           `gorm:"not null;index" json:"post_id"`
 	Post      Post           `gorm:"foreignKey:PostID" json:"post,omitempty"`
+	ParentID  *uint          `gorm:"index" json:"parent_id"`
+	Depth     int            `gorm:"not null;default:0" json:"depth"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 type CreateCommentRequest struct {
+	Content  string `json:"content" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
 	Content string `json:"content" binding:"required"`
 }
+
+// CommentNode 是评论的展示结构，Deleted 为 true 时表示占位的已删除评论。
+type CommentNode struct {
+	Comment
+	Deleted bool           `json:"deleted,omitempty"`
+	Replies []*CommentNode `json:"replies,omitempty"`
+}
+
+// BuildCommentThread 将同一篇文章下按时间排序的评论（包含软删除记录）整理成树。
+// 已删除的评论只有在子树中仍有未删除回复时才保留，并以占位内容展示。
+func BuildCommentThread(comments []Comment) []*CommentNode {
+	nodes := make(map[uint]*CommentNode, len(comments))
+	ordered := make([]*CommentNode, 0, len(comments))
+	for _, comment := range comments {
+		node := &CommentNode{Comment: comment, Deleted: comment.DeletedAt.Valid}
+		nodes[comment.ID] = node
+		ordered = append(ordered, node)
+	}
+
+	var candidates []*CommentNode
+	children := make(map[uint][]*CommentNode)
+	for _, node := range ordered {
+		if node.ParentID != nil {
+			if _, ok := nodes[*node.ParentID]; ok {
+				children[*node.ParentID] = append(children[*node.ParentID], node)
+				continue
+			}
+		}
+		candidates = append(candidates, node)
+	}
+
+	var keep func(node *CommentNode) bool
+	keep = func(node *CommentNode) bool {
+		for _, child := range children[node.ID] {
+			if keep(child) {
+				node.Replies = append(node.Replies, child)
+			}
+		}
+		if node.Deleted {
+			if len(node.Replies) == 0 {
+				return false
+			}
+			node.Content = DeletedCommentPlaceholder
+			node.UserID = 0
+			node.User = User{}
+		}
+		return true
+	}
+
+	roots := []*CommentNode{}
+	for _, node := range candidates {
+		if keep(node) {
+			roots = append(roots, node)
+		}
+	}
+
+	return roots
+}
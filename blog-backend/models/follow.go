@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// MaxFollowing 是每个用户最多关注的人数，同时限制了关注动态查询中作者列表的长度。
+const MaxFollowing = 5000
+
+// Follow 是用户之间的关注关系，FollowerID 关注 FolloweeID。
+type Follow struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	FollowerID uint      `gorm:"not null;uniqueIndex:idx_follows_pair,priority:1" json:"follower_id"`
+	FolloweeID uint      `gorm:"not null;uniqueIndex:idx_follows_pair,priority:2;index" json:"followee_id"`
+	Follower   User      `gorm:"foreignKey:FollowerID" json:"-"`
+	Followee   User      `gorm:"foreignKey:FolloweeID" json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FollowProfile 是粉丝或关注列表中的一项：对方的公开资料和关注时间。
+type FollowProfile struct {
+	PublicProfile
+	FollowedAt time.Time `json:"followed_at"`
+}
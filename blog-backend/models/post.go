@@ -1,29 +1,71 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+const (
+	PostStatusDraft     = "draft"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
+// postStatusTransitions 列出允许的状态变更：草稿只能发布，已发布的文章可以归档，归档后可以重新发布。
+var postStatusTransitions = map[string][]string{
+	PostStatusDraft:     {PostStatusPublished},
+	PostStatusPublished: {PostStatusArchived},
+	PostStatusArchived:  {PostStatusPublished},
+}
+
+// Post 的 CommentCount 是未删除评论数的冗余计数，由 repository 在写入评论的同一事务中维护，
+// `blog-backend reconcile` 可以从源表重新核对；ViewCount 是累计浏览量。
+// Reactions 不存储在 posts 表中，由 service 在返回前按当前用户填充。
 type Post struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Title     string         `gorm:"not null" json:"title"`
-	Content   string         `gorm:"type:text;not null" json:"content"`
-	UserID    uint           `gorm:"not null" json:"user_id"`
-	User      User           `gorm:"foreignKey:UserID" json:"user"`
-	Comments  []Comment      `gorm:"foreignKey:PostID" json:"comments,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           uint              `gorm:"primaryKey" json:"id"`
+	Title        string            `gorm:"not null" json:"title"`
+	Content      string            `gorm:"type:text;not null" json:"content"`
+	Status       string            `gorm:"type:varchar(20);default:'draft';index;not null" json:"status"`
+	PublishAt    *time.Time        `gorm:"index" json:"publish_at,omitempty"`
+	PublishedAt  *time.Time        `json:"published_at,omitempty"`
+	UserID       uint              `gorm:"not null" json:"user_id"`
+	User         User              `gorm:"foreignKey:UserID" json:"user"`
+	CategoryID   *uint             `gorm:"index" json:"category_id"`
+	Category     *Category         `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Tags         []Tag             `gorm:"many2many:post_tags" json:"tags"`
+	Comments     []Comment         `gorm:"foreignKey:PostID" json:"comments,omitempty"`
+	CommentCount int64             `gorm:"not null;default:0" json:"comment_count"`
+	ViewCount    int64             `gorm:"not null;default:0" json:"view_count"`
+	Reactions    []ReactionSummary `gorm:"-" json:"reactions"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+func (p *Post) IsPublished() bool {
+	return p.Status == PostStatusPublished
+}
+
+// CanTransitionTo 判断文章能否从当前状态变更为 status。
+func (p *Post) CanTransitionTo(status string) bool {
+	return slices.Contains(postStatusTransitions[p.Status], status)
 }
 
 type CreatePostRequest struct {
-	Title   string `json:"title" binding:"required"`
-	Content string `json:"content" binding:"required"`
+	Title      string     `json:"title" binding:"required"`
+	Content    string     `json:"content" binding:"required"`
+	PublishAt  *time.Time `json:"publish_at"`
+	CategoryID *uint      `json:"category_id"`
+	Tags       []string   `json:"tags" binding:"max=10,dive,max=50"`
 }
 
+// UpdatePostRequest 中 Tags 为 nil 时保持原标签不变，传空数组则清空标签。
 type UpdatePostRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title      string     `json:"title"`
+	Content    string     `json:"content"`
+	PublishAt  *time.Time `json:"publish_at"`
+	CategoryID *uint      `json:"category_id"`
+	Tags       *[]string  `json:"tags" binding:"omitempty,max=10,dive,max=50"`
 }
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// PostViewStat 是文章按小时聚合的浏览量，Bucket 为该小时的起始时间，用于计算热门文章。
+type PostViewStat struct {
+	PostID uint      `gorm:"primaryKey" json:"post_id"`
+	Bucket time.Time `gorm:"primaryKey;index" json:"bucket"`
+	Views  int64     `gorm:"not null;default:0" json:"views"`
+}
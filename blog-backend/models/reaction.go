@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// 文章表态的类型，新增类型时同时修改 ReactionRequest 的 oneof 校验。
+const (
+	ReactionLike  = "like"
+	ReactionLove  = "love"
+	ReactionLaugh = "laugh"
+	ReactionWow   = "wow"
+	ReactionSad   = "sad"
+	ReactionAngry = "angry"
+)
+
+// ReactionTypes 是所有表态类型，也是返回汇总时的顺序。
+var ReactionTypes = []string{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry}
+
+// Reaction 是用户对文章的一次表态，唯一索引保证同一用户对同一文章的每种类型最多一次。
+type Reaction struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	PostID    uint      `gorm:"not null;uniqueIndex:idx_reactions_unique,priority:1" json:"post_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_reactions_unique,priority:2;index" json:"user_id"`
+	Type      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_reactions_unique,priority:3" json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReactionSummary 是文章某种表态的数量，Reacted 表示当前用户是否已经留下该表态。
+type ReactionSummary struct {
+	Type    string `json:"type"`
+	Count   int64  `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+// SummarizeReactions 按 ReactionTypes 的顺序返回所有类型的汇总，没有表态的类型数量为 0。
+func SummarizeReactions(counts map[string]int64, reacted map[string]bool) []ReactionSummary {
+	summaries := make([]ReactionSummary, len(ReactionTypes))
+	for i, t := range ReactionTypes {
+		summaries[i] = ReactionSummary{Type: t, Count: counts[t], Reacted: reacted[t]}
+	}
+	return summaries
+}
+
+type ReactionRequest struct {
+	Type string `json:"type" form:"type" binding:"required,oneof=like love laugh wow sad angry"`
+}
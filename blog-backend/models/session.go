@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// Session 记录一次登录签发的刷新令牌。每次刷新都会轮换出同一 FamilyID 下的新记录，
+// 旧记录被标记为 RotatedAt；若已轮换的刷新令牌再次出现，则整个 family 被吊销。
+type Session struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	UserID           uint       `gorm:"index;not null" json:"user_id"`
+	FamilyID         string     `gorm:"type:varchar(64);index;not null" json:"family_id"`
+	RefreshTokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	AccessTokenID    string     `gorm:"type:varchar(64);index;not null" json:"-"`
+	ExpiresAt        time.Time  `gorm:"not null" json:"expires_at"`
+	RotatedAt        *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// IsActive 表示该会话签发的访问令牌仍然可用。
+func (s *Session) IsActive() bool {
+	return s.RotatedAt == nil && s.RevokedAt == nil
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
@@ -1,22 +1,41 @@
 package models
 
 import (
+	"strconv"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+const (
+	RoleReader    = "reader"
+	RoleAuthor    = "author"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// User 的 PostCount 是未删除文章（包括草稿）数量的冗余计数，由 repository 在写入文章的同一事务中维护；
+// FollowerCount 和 FollowingCount 是粉丝数和关注数，在关注和取消关注的同一事务中维护。
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"type:varchar(100);uniqueIndex;not null" json:"username"`
-	Email     string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
-	Password  string         `gorm:"not null" json:"-"`
-	Posts     []Post         `gorm:"foreignKey:UserID" json:"-"`
-	Comments  []Comment      `gorm:"foreignKey:UserID" json:"-"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Username        string         `gorm:"type:varchar(100);uniqueIndex;not null" json:"username"`
+	Email           string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
+	Password        string         `gorm:"not null" json:"-"`
+	Role            string         `gorm:"type:varchar(20);default:'author';index;not null" json:"role"`
+	Nickname        string         `gorm:"type:varchar(50);not null;default:''" json:"nickname"`
+	Avatar          string         `gorm:"type:varchar(500);not null;default:''" json:"avatar"`
+	Bio             string         `gorm:"type:varchar(500);not null;default:''" json:"bio"`
+	IsActive        bool           `gorm:"not null;default:true" json:"is_active"`
+	PostCount       int64          `gorm:"not null;default:0" json:"post_count"`
+	FollowerCount   int64          `gorm:"not null;default:0" json:"follower_count"`
+	FollowingCount  int64          `gorm:"not null;default:0" json:"following_count"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	Posts           []Post         `gorm:"foreignKey:UserID" json:"-"`
+	Comments        []Comment      `gorm:"foreignKey:UserID" json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 func (u *User) HashPassword(password string) error {
@@ -31,3 +50,89 @@ func (u *User) HashPassword(password string) error {
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
+
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// DeletedUsernamePrefix 是注销账号改写后的用户名前缀，注册时不允许使用。
+const DeletedUsernamePrefix = "deleted-"
+
+// DeletedIdentity 返回注销账号改写后的用户名和邮箱，用于释放唯一索引，使原用户名和邮箱可以重新注册。
+// 改写后的邮箱不是合法邮箱地址，不会与注册的邮箱冲突。
+func DeletedIdentity(id uint) (username, email string) {
+	username = DeletedUsernamePrefix + strconv.FormatUint(uint64(id), 10)
+	return username, username
+}
+
+func (u *User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicProfile 是对外公开的用户资料，不包含邮箱等私人信息。
+type PublicProfile struct {
+	ID             uint      `json:"id"`
+	Username       string    `json:"username"`
+	Nickname       string    `json:"nickname"`
+	Avatar         string    `json:"avatar"`
+	Bio            string    `json:"bio"`
+	PostCount      int64     `json:"post_count"`
+	FollowerCount  int64     `json:"follower_count"`
+	FollowingCount int64     `json:"following_count"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (u *User) Public() PublicProfile {
+	return PublicProfile{
+		ID:             u.ID,
+		Username:       u.Username,
+		Nickname:       u.Nickname,
+		Avatar:         u.Avatar,
+		Bio:            u.Bio,
+		PostCount:      u.PostCount,
+		FollowerCount:  u.FollowerCount,
+		FollowingCount: u.FollowingCount,
+		CreatedAt:      u.CreatedAt,
+	}
+}
+
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required,min=3"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=reader author moderator admin"`
+}
+
+type UpdateUserStatusRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+// UpdateProfileRequest 只修改提供的字段，传空字符串表示清空。
+type UpdateProfileRequest struct {
+	Nickname *string `json:"nickname" binding:"omitempty,max=50"`
+	Avatar   *string `json:"avatar" binding:"omitempty,max=500"`
+	Bio      *string `json:"bio" binding:"omitempty,max=500"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// ConfirmPasswordRequest 用于停用、删除账号等需要再次输入密码确认的操作。
+type ConfirmPasswordRequest struct {
+	Password string `json:"password" binding:"required"`
+}
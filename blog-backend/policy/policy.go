@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"github.com/task/go_learn_task/blog-backend/models"
+)
+
+type Permission string
+
+const (
+	PermCreateComment    Permission = "comment:create"
+	PermCreatePost       Permission = "post:create"
+	PermDeleteAnyPost    Permission = "post:delete:any"
+	PermDeleteAnyComment Permission = "comment:delete:any"
+	PermUpdateAnyPost    Permission = "post:update:any"
+	PermManageUsers      Permission = "user:manage"
+	PermManageCategories Permission = "category:manage"
+)
+
+// rolePermissions 定义每个角色拥有的权限，高等级角色包含低等级角色的全部权限。
+var rolePermissions = map[string][]Permission{
+	models.RoleReader: {
+		PermCreateComment,
+	},
+	models.RoleAuthor: {
+		PermCreateComment,
+		PermCreatePost,
+	},
+	models.RoleModerator: {
+		PermCreateComment,
+		PermCreatePost,
+		PermDeleteAnyPost,
+		PermDeleteAnyComment,
+	},
+	models.RoleAdmin: {
+		PermCreateComment,
+		PermCreatePost,
+		PermDeleteAnyPost,
+		PermDeleteAnyComment,
+		PermUpdateAnyPost,
+		PermManageUsers,
+		PermManageCategories,
+	},
+}
+
+// Can 判断用户角色是否拥有指定权限。
+func Can(user *models.User, perm Permission) bool {
+	if user == nil {
+		return false
+	}
+	for _, p := range rolePermissions[user.Role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+func isOwner(user *models.User, ownerID uint) bool {
+	return user != nil && user.ID == ownerID
+}
+
+// CanViewPost 已发布的文章所有人可见，草稿和归档文章只有作者和版主/管理员可见。
+func CanViewPost(user *models.User, post *models.Post) bool {
+	return post.IsPublished() || isOwner(user, post.UserID) || Can(user, PermDeleteAnyPost)
+}
+
+func CanUpdatePost(user *models.User, post *models.Post) bool {
+	return isOwner(user, post.UserID) || Can(user, PermUpdateAnyPost)
+}
+
+func CanDeletePost(user *models.User, post *models.Post) bool {
+	return isOwner(user, post.UserID) || Can(user, PermDeleteAnyPost)
+}
+
+func CanUpdateComment(user *models.User, comment *models.Comment) bool {
+	return isOwner(user, comment.UserID)
+}
+
+// CanDeleteComment 评论作者、所属文章作者以及版主/管理员都可以删除评论。
+func CanDeleteComment(user *models.User, comment *models.Comment, post *models.Post) bool {
+	return isOwner(user, comment.UserID) || isOwner(user, post.UserID) || Can(user, PermDeleteAnyComment)
+}
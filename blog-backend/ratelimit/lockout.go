@@ -0,0 +1,125 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LockoutPolicy 描述登录失败锁定规则：连续失败 Threshold 次后锁定 Duration，
+// 之后每多失败一次锁定时间翻倍，最长 MaxDuration。Window 内没有新的失败则计数清零。
+type LockoutPolicy struct {
+	Threshold   int
+	Duration    time.Duration
+	MaxDuration time.Duration
+	Window      time.Duration
+}
+
+func (p LockoutPolicy) Enabled() bool {
+	return p.Threshold > 0 && p.Duration > 0
+}
+
+// lockDuration 返回第 failures 次失败后的锁定时间。
+func (p LockoutPolicy) lockDuration(failures int) time.Duration {
+	if failures < p.Threshold {
+		return 0
+	}
+	d := p.Duration
+	for i := p.Threshold; i < failures; i++ {
+		d *= 2
+		if p.MaxDuration > 0 && d >= p.MaxDuration {
+			return p.MaxDuration
+		}
+	}
+	return d
+}
+
+// LockoutStore 记录每个 key 的连续失败次数和锁定截止时间。
+type LockoutStore interface {
+	// LockedUntil 返回 key 的锁定截止时间，未锁定时返回零值。
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	// RecordFailure 记录一次失败，返回更新后的锁定截止时间。
+	RecordFailure(ctx context.Context, key string, policy LockoutPolicy) (time.Time, error)
+	Reset(ctx context.Context, key string) error
+}
+
+type failureRecord struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// lockoutIdleTTL 是 Window 为 0（失败次数不自动清零）时，未锁定的记录在没有新的失败后保留的时长。
+const lockoutIdleTTL = 24 * time.Hour
+
+// expired 判断记录是否已解锁且失败计数已过期，可以从内存中删除。
+func (r *failureRecord) expired(now time.Time, policy LockoutPolicy) bool {
+	if now.Before(r.lockedUntil) {
+		return false
+	}
+	idle := policy.Window
+	if idle <= 0 {
+		idle = lockoutIdleTTL
+	}
+	return now.Sub(r.lastFailure) > idle
+}
+
+// MemoryLockoutStore 是进程内的 LockoutStore 实现。key 来自客户端提交的登录账号，
+// 包括不存在的邮箱，因此定期清理过期的记录，避免内存无限增长。
+type MemoryLockoutStore struct {
+	mu      sync.Mutex
+	records map[string]*failureRecord
+	ops     int
+	now     func() time.Time
+}
+
+var _ LockoutStore = (*MemoryLockoutStore)(nil)
+
+func NewMemoryLockoutStore() *MemoryLockoutStore {
+	return &MemoryLockoutStore{records: make(map[string]*failureRecord), now: time.Now}
+}
+
+func (s *MemoryLockoutStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.records[key]; ok && s.now().Before(r.lockedUntil) {
+		return r.lockedUntil, nil
+	}
+	return time.Time{}, nil
+}
+
+func (s *MemoryLockoutStore) RecordFailure(ctx context.Context, key string, policy LockoutPolicy) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.ops++
+	if s.ops%sweepEvery == 0 {
+		for k, r := range s.records {
+			if r.expired(now, policy) {
+				delete(s.records, k)
+			}
+		}
+	}
+
+	r, ok := s.records[key]
+	if !ok || (policy.Window > 0 && now.Sub(r.lastFailure) > policy.Window && !now.Before(r.lockedUntil)) {
+		r = &failureRecord{}
+		s.records[key] = r
+	}
+	r.failures++
+	r.lastFailure = now
+	if d := policy.lockDuration(r.failures); d > 0 {
+		r.lockedUntil = now.Add(d)
+	}
+	return r.lockedUntil, nil
+}
+
+func (s *MemoryLockoutStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// LoginGuard 按账号（邮箱）限制登录频率，并在连续失败后锁定账号。
+type LoginGuard struct {
+	store    Store
+	lockouts LockoutStore
+	limit    Limit
+	policy   LockoutPolicy
+	now      func() time.Time
+}
+
+func NewLoginGuard(store Store, lockouts LockoutStore, limit Limit, policy LockoutPolicy) *LoginGuard {
+	return &LoginGuard{store: store, lockouts: lockouts, limit: limit, policy: policy, now: time.Now}
+}
+
+func accountKey(email string) string {
+	return "login:account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// Allow 在校验密码之前调用，账号被锁定或超过频率限制时返回需要等待的时间。
+func (g *LoginGuard) Allow(ctx context.Context, email string) (time.Duration, error) {
+	key := accountKey(email)
+
+	if g.policy.Enabled() {
+		until, err := g.lockouts.LockedUntil(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		if wait := until.Sub(g.now()); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	result, err := g.store.Take(ctx, key, g.limit)
+	if err != nil {
+		return 0, err
+	}
+	if !result.Allowed {
+		return result.RetryAfter, nil
+	}
+	return 0, nil
+}
+
+// Failed 记录一次失败的登录，返回触发锁定时需要等待的时间。
+func (g *LoginGuard) Failed(ctx context.Context, email string) (time.Duration, error) {
+	if !g.policy.Enabled() {
+		return 0, nil
+	}
+	until, err := g.lockouts.RecordFailure(ctx, accountKey(email), g.policy)
+	if err != nil {
+		return 0, err
+	}
+	if wait := until.Sub(g.now()); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
+
+// Succeeded 在登录成功后清除失败计数。
+func (g *LoginGuard) Succeeded(ctx context.Context, email string) error {
+	if !g.policy.Enabled() {
+		return nil
+	}
+	return g.lockouts.Reset(ctx, accountKey(email))
+}
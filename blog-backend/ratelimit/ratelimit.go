@@ -0,0 +1,97 @@
+// Package ratelimit 提供令牌桶限流和登录失败锁定。存储通过接口抽象，默认使用进程内存，
+// 多实例部署时可以替换为 Redis 等共享存储。
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit 表示每 Per 时间内最多 Count 次请求，桶容量同样为 Count。Count 为 0 表示不限流。
+type Limit struct {
+	Count int
+	Per   time.Duration
+}
+
+func (l Limit) Enabled() bool {
+	return l.Count > 0 && l.Per > 0
+}
+
+// rate 返回每秒补充的令牌数。
+func (l Limit) rate() float64 {
+	return float64(l.Count) / l.Per.Seconds()
+}
+
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Store 是令牌桶的存储，Take 从 key 对应的桶中取出一个令牌。
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full 是桶重新装满的时间，之后该桶可以安全删除
+	full time.Time
+}
+
+// MemoryStore 是进程内的令牌桶存储，只适用于单实例部署。
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	ops     int
+	now     func() time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// sweepEvery 控制清理已装满的桶的频率，避免长期运行时 key 无限增长。
+const sweepEvery = 1024
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if !limit.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.ops++
+	if s.ops%sweepEvery == 0 {
+		for k, b := range s.buckets {
+			if !now.Before(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+	}
+
+	rate := limit.rate()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Count), last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Count), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+		return Result{Allowed: false, RetryAfter: wait}, nil
+	}
+
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(limit.Count) - b.tokens) / rate * float64(time.Second)))
+	return Result{Allowed: true, Remaining: int(b.tokens)}, nil
+}
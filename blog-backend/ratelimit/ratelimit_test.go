@@ -0,0 +1,136 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	limit := Limit{Count: 3, Per: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		if r, _ := store.Take(ctx, "k", limit); !r.Allowed {
+			t.Fatalf("request %d rejected", i)
+		}
+	}
+	r, _ := store.Take(ctx, "k", limit)
+	if r.Allowed || r.RetryAfter != 20*time.Second {
+		t.Fatalf("4th request: allowed=%v retry=%v, want rejected after 20s", r.Allowed, r.RetryAfter)
+	}
+	if r, _ := store.Take(ctx, "other", limit); !r.Allowed {
+		t.Fatal("keys must not share buckets")
+	}
+
+	clock.Advance(20 * time.Second)
+	if r, _ := store.Take(ctx, "k", limit); !r.Allowed {
+		t.Fatal("token not refilled after 20s")
+	}
+	if r, _ := store.Take(ctx, "k", limit); r.Allowed {
+		t.Fatal("only one token should have been refilled")
+	}
+
+	if r, _ := store.Take(ctx, "k", Limit{}); !r.Allowed {
+		t.Fatal("disabled limit must allow")
+	}
+}
+
+func TestMemoryLockoutStoreSweepsExpiredRecords(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	store := NewMemoryLockoutStore()
+	store.now = clock.Now
+	policy := LockoutPolicy{Threshold: 1, Duration: time.Hour, Window: time.Minute}
+
+	// 已锁定的记录在锁定期内不会被清理
+	store.RecordFailure(ctx, "locked", policy)
+	for i := 1; i < sweepEvery-1; i++ {
+		store.RecordFailure(ctx, fmt.Sprintf("unknown-%d@example.com", i), LockoutPolicy{Threshold: 5, Duration: time.Hour, Window: time.Minute})
+	}
+	if n := len(store.records); n != sweepEvery-1 {
+		t.Fatalf("records = %d, want %d", n, sweepEvery-1)
+	}
+
+	clock.Advance(2 * time.Minute)
+	store.RecordFailure(ctx, "fresh", policy)
+	if n := len(store.records); n != 2 {
+		t.Fatalf("records after sweep = %d, want 2 (locked and fresh)", n)
+	}
+	if until, _ := store.LockedUntil(ctx, "locked"); until.IsZero() {
+		t.Error("locked record was swept")
+	}
+}
+
+func TestLoginGuardLockout(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	lockouts := NewMemoryLockoutStore()
+	lockouts.now = clock.Now
+	guard := NewLoginGuard(NewMemoryStore(), lockouts, Limit{}, LockoutPolicy{
+		Threshold:   3,
+		Duration:    time.Minute,
+		MaxDuration: 3 * time.Minute,
+		Window:      10 * time.Minute,
+	})
+	guard.now = clock.Now
+
+	fail := func() time.Duration {
+		t.Helper()
+		wait, err := guard.Failed(ctx, "Alice@Example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return wait
+	}
+
+	if fail() != 0 || fail() != 0 {
+		t.Fatal("locked before reaching the threshold")
+	}
+	if wait := fail(); wait != time.Minute {
+		t.Fatalf("3rd failure lock = %v, want 1m", wait)
+	}
+	// 邮箱大小写不影响计数
+	if wait, _ := guard.Allow(ctx, "alice@example.com"); wait != time.Minute {
+		t.Fatalf("Allow while locked = %v, want 1m", wait)
+	}
+
+	clock.Advance(time.Minute)
+	if wait, _ := guard.Allow(ctx, "alice@example.com"); wait != 0 {
+		t.Fatalf("still locked after lock expired: %v", wait)
+	}
+	if wait := fail(); wait != 2*time.Minute {
+		t.Fatalf("4th failure lock = %v, want 2m (doubled)", wait)
+	}
+	clock.Advance(2 * time.Minute)
+	if wait := fail(); wait != 3*time.Minute {
+		t.Fatalf("5th failure lock = %v, want 3m (capped)", wait)
+	}
+
+	if err := guard.Succeeded(ctx, "alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if wait, _ := guard.Allow(ctx, "alice@example.com"); wait != 0 {
+		t.Fatalf("locked after successful login reset: %v", wait)
+	}
+
+	// 超过 Window 没有失败时计数清零
+	fail()
+	fail()
+	clock.Advance(11 * time.Minute)
+	if wait := fail(); wait != 0 {
+		t.Fatalf("failures outside the window should not accumulate, lock = %v", wait)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/database"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+const reconcileUsage = `usage: blog-backend reconcile [--fix]
+
+Recompute posts.comment_count, users.post_count, users.follower_count and
+users.following_count from the source tables and report rows whose stored
+value has drifted. With --fix the drifted rows
+are repaired; without it the command exits with status 1 if any drift is found.`
+
+// runReconcileCommand 处理 `blog-backend reconcile ...` 子命令。
+func runReconcileCommand(cfg *config.Config, args []string) {
+	fix := false
+	for _, arg := range args {
+		switch arg {
+		case "--fix":
+			fix = true
+		default:
+			fmt.Fprintln(os.Stderr, reconcileUsage)
+			os.Exit(2)
+		}
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+
+	ctx := context.Background()
+	counters := repository.NewCounterRepository(db)
+	drifts, err := counters.Drift(ctx)
+	if err != nil {
+		fatal("failed to check counters", err)
+	}
+	for _, d := range drifts {
+		fmt.Printf("%s.%s  id=%-8d stored=%-8d actual=%d\n", d.Table, d.Column, d.ID, d.Stored, d.Actual)
+	}
+	if len(drifts) == 0 {
+		fmt.Println("all counters are consistent")
+		return
+	}
+	if !fix {
+		fmt.Printf("%d counter(s) drifted, run with --fix to repair\n", len(drifts))
+		os.Exit(1)
+	}
+
+	if err := counters.Repair(ctx, drifts); err != nil {
+		fatal("failed to repair counters", err)
+	}
+	fmt.Printf("repaired %d counter(s)\n", len(drifts))
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"gorm.io/gorm"
+)
+
+type gormBookmarkRepository struct {
+	db *gorm.DB
+}
+
+func NewBookmarkRepository(db *gorm.DB) BookmarkRepository {
+	return &gormBookmarkRepository{db: db}
+}
+
+func bookmarkCursorKey(b *models.Bookmark) (time.Time, uint) {
+	return b.CreatedAt, b.ID
+}
+
+func (r *gormBookmarkRepository) Create(ctx context.Context, bookmark *models.Bookmark) error {
+	err := r.db.WithContext(ctx).Omit("Post").Create(bookmark).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (r *gormBookmarkRepository) Delete(ctx context.Context, userID, postID uint) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND post_id = ?", userID, postID).Delete(&models.Bookmark{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormBookmarkRepository) List(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Bookmark], error) {
+	query := r.db.WithContext(ctx).Model(&models.Bookmark{}).
+		Joins("JOIN posts ON posts.id = bookmarks.post_id AND posts.deleted_at IS NULL").
+		Where("bookmarks.user_id = ? AND posts.status = ?", userID, models.PostStatusPublished)
+	return utils.Paginate(query, params, "bookmarks", bookmarkCursorKey, preloadItemPost)
+}
+
+// preloadItemPost 加载收藏和列表条目中的文章及其作者、分类、标签。
+func preloadItemPost(db *gorm.DB) *gorm.DB {
+	return db.Preload("Post").Preload("Post.User").Preload("Post.Category").Preload("Post.Tags")
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+type gormCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &gormCategoryRepository{db: db}
+}
+
+func (r *gormCategoryRepository) List(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.WithContext(ctx).Order("name").Find(&categories).Error
+	return categories, err
+}
+
+func (r *gormCategoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.WithContext(ctx).First(&category, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &category, nil
+}
+
+func (r *gormCategoryRepository) ExistsByNameOrSlug(ctx context.Context, name, slug string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&models.Category{}).Where("(name = ? OR slug = ?)", name, slug)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormCategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+func (r *gormCategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	return r.db.WithContext(ctx).Save(category).Error
+}
+
+func (r *gormCategoryRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Post{}).Where("category_id = ?", id).
+			Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Category{}, id).Error
+	})
+}
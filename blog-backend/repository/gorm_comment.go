@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"gorm.io/gorm"
+)
+
+type gormCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &gormCommentRepository{db: db}
+}
+
+func commentCursorKey(cm *models.Comment) (time.Time, uint) {
+	return cm.CreatedAt, cm.ID
+}
+
+func preloadCommentUser(db *gorm.DB) *gorm.DB {
+	return db.Preload("User")
+}
+
+func (r *gormCommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	db := r.db.WithContext(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		return adjustCounter(tx, &models.Post{}, comment.PostID, "comment_count", 1)
+	})
+	if err != nil {
+		return err
+	}
+	return db.Preload("User").First(comment, comment.ID).Error
+}
+
+func (r *gormCommentRepository) FindByID(ctx context.Context, postID, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := r.db.WithContext(ctx).Preload("User").Where("post_id = ?", postID).First(&comment, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &comment, nil
+}
+
+func (r *gormCommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	return r.db.WithContext(ctx).Model(comment).Update("content", comment.Content).Error
+}
+
+func (r *gormCommentRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment models.Comment
+		if err := tx.Select("id", "post_id").First(&comment, id).Error; err != nil {
+			return translateError(err)
+		}
+		result := tx.Delete(&models.Comment{}, id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return adjustCounter(tx, &models.Post{}, comment.PostID, "comment_count", -1)
+	})
+}
+
+func (r *gormCommentRepository) Restore(ctx context.Context, postID, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Comment{}).
+			Where("id = ? AND post_id = ? AND deleted_at IS NOT NULL", id, postID).
+			UpdateColumn("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return adjustCounter(tx, &models.Post{}, postID, "comment_count", 1)
+	})
+}
+
+func (r *gormCommentRepository) List(ctx context.Context, postID uint, params *utils.PageParams) (*utils.PageResult[models.Comment], error) {
+	query := r.db.WithContext(ctx).Model(&models.Comment{}).Where("comments.post_id = ?", postID)
+	return utils.Paginate(query, params, "comments", commentCursorKey, preloadCommentUser)
+}
+
+func (r *gormCommentRepository) ListThread(ctx context.Context, postID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := r.db.WithContext(ctx).Unscoped().Preload("User").
+		Where("post_id = ?", postID).
+		Order("created_at, id").
+		Find(&comments).Error
+	return comments, err
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// counter 描述一个冗余计数列以及如何从源表统计它。
+// join 用于批量找出不一致的记录，actual 是修复时使用的相关子查询，两者的条件必须一致。
+type counter struct {
+	table  string
+	column string
+	source string
+	join   string
+	actual string
+}
+
+// visibleUserIDs 选出未删除且未停用的用户，关注计数只统计这些对方。
+// 包一层 DISTINCT 派生表是为了让 MySQL 物化子查询，否则 UPDATE users 时不能在子查询中读取 users。
+const visibleUserIDs = "SELECT id FROM (SELECT DISTINCT id FROM users WHERE deleted_at IS NULL AND is_active = TRUE) AS visible_users"
+
+var counters = []counter{
+	{
+		table:  "posts",
+		column: "comment_count",
+		source: "comments",
+		join:   "LEFT JOIN comments ON comments.post_id = posts.id AND comments.deleted_at IS NULL",
+		actual: "(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL)",
+	},
+	{
+		table:  "users",
+		column: "post_count",
+		source: "posts",
+		join:   "LEFT JOIN posts ON posts.user_id = users.id AND posts.deleted_at IS NULL",
+		actual: "(SELECT COUNT(*) FROM posts WHERE posts.user_id = users.id AND posts.deleted_at IS NULL)",
+	},
+	{
+		table:  "users",
+		column: "follower_count",
+		source: "follows",
+		join:   "LEFT JOIN follows ON follows.followee_id = users.id AND follows.follower_id IN (" + visibleUserIDs + ")",
+		actual: "(SELECT COUNT(*) FROM follows WHERE follows.followee_id = users.id AND follows.follower_id IN (" + visibleUserIDs + "))",
+	},
+	{
+		table:  "users",
+		column: "following_count",
+		source: "follows",
+		join:   "LEFT JOIN follows ON follows.follower_id = users.id AND follows.followee_id IN (" + visibleUserIDs + ")",
+		actual: "(SELECT COUNT(*) FROM follows WHERE follows.follower_id = users.id AND follows.followee_id IN (" + visibleUserIDs + "))",
+	},
+}
+
+type gormCounterRepository struct {
+	db *gorm.DB
+}
+
+func NewCounterRepository(db *gorm.DB) CounterRepository {
+	return &gormCounterRepository{db: db}
+}
+
+func (r *gormCounterRepository) Drift(ctx context.Context) ([]CounterDrift, error) {
+	var drifts []CounterDrift
+	for _, c := range counters {
+		// 已软删除的记录同样核对，恢复后计数才正确
+		var rows []struct {
+			ID     uint
+			Stored int64
+			Actual int64
+		}
+		countExpr := "COUNT(" + c.source + ".id)"
+		err := r.db.WithContext(ctx).Table(c.table).
+			Select(c.table + ".id AS id, " + c.table + "." + c.column + " AS stored, " + countExpr + " AS actual").
+			Joins(c.join).
+			Group(c.table + ".id, " + c.table + "." + c.column).
+			Having(c.table + "." + c.column + " <> " + countExpr).
+			Order(c.table + ".id").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			drifts = append(drifts, CounterDrift{Table: c.table, Column: c.column, ID: row.ID, Stored: row.Stored, Actual: row.Actual})
+		}
+	}
+	return drifts, nil
+}
+
+// Repair 使用相关子查询在更新时重新统计，而不是写入 Drift 读到的值，避免覆盖期间的并发修改。
+func (r *gormCounterRepository) Repair(ctx context.Context, drifts []CounterDrift) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, c := range counters {
+			var ids []uint
+			for _, d := range drifts {
+				if d.Table == c.table && d.Column == c.column {
+					ids = append(ids, d.ID)
+				}
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			err := tx.Table(c.table).Where("id IN ?", ids).
+				UpdateColumn(c.column, gorm.Expr(c.actual)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
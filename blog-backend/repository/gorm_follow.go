@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"gorm.io/gorm"
+)
+
+type gormFollowRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &gormFollowRepository{db: db}
+}
+
+func followCursorKey(f *models.Follow) (time.Time, uint) {
+	return f.CreatedAt, f.ID
+}
+
+func (r *gormFollowRepository) Create(ctx context.Context, follow *models.Follow) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Follower", "Followee").Create(follow).Error; err != nil {
+			return err
+		}
+		return adjustFollowCounts(tx, follow.FollowerID, follow.FolloweeID, 1)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (r *gormFollowRepository) Delete(ctx context.Context, followerID, followeeID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 并发取消关注时只有真正删除了记录的请求调整计数
+		result := tx.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&models.Follow{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return adjustFollowCounts(tx, followerID, followeeID, -1)
+	})
+}
+
+func (r *gormFollowRepository) Followers(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Follow], error) {
+	query := r.activeUsers(ctx, "follows.follower_id").Where("follows.followee_id = ?", userID)
+	return utils.Paginate(query, params, "follows", followCursorKey, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Follower")
+	})
+}
+
+func (r *gormFollowRepository) Following(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Follow], error) {
+	query := r.activeUsers(ctx, "follows.followee_id").Where("follows.follower_id = ?", userID)
+	return utils.Paginate(query, params, "follows", followCursorKey, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Followee")
+	})
+}
+
+func (r *gormFollowRepository) FolloweeIDs(ctx context.Context, followerID uint) ([]uint, error) {
+	var ids []uint
+	err := r.activeUsers(ctx, "follows.followee_id").
+		Where("follows.follower_id = ?", followerID).
+		Pluck("follows.followee_id", &ids).Error
+	return ids, err
+}
+
+// activeUsers 返回关注关系的查询，并排除 column 一侧已删除或停用的用户。
+func (r *gormFollowRepository) activeUsers(ctx context.Context, column string) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.Follow{}).
+		Joins("JOIN users ON users.id = "+column+" AND users.deleted_at IS NULL AND users.is_active = ?", true)
+}
+
+// adjustFollowCounts 调整一条关注关系对双方计数的影响。计数只包含未删除且未停用的对方，
+// 与列表的过滤规则一致：关注者可见时才计入粉丝数，被关注者可见时才计入关注数。
+func adjustFollowCounts(tx *gorm.DB, followerID, followeeID uint, delta int64) error {
+	var visible []uint
+	err := tx.Model(&models.User{}).Where("id IN ? AND is_active = ?", []uint{followerID, followeeID}, true).
+		Pluck("id", &visible).Error
+	if err != nil {
+		return err
+	}
+	if slices.Contains(visible, followeeID) {
+		if err := adjustCounter(tx, &models.User{}, followerID, "following_count", delta); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(visible, followerID) {
+		return adjustCounter(tx, &models.User{}, followeeID, "follower_count", delta)
+	}
+	return nil
+}
+
+// shiftFollowCounts 在用户被停用、删除（delta 为 -1）或重新启用（delta 为 1）时，
+// 调整其关注的人的粉丝数和其粉丝的关注数。
+func shiftFollowCounts(tx *gorm.DB, userID uint, delta int64) error {
+	shifts := []struct {
+		column   string
+		subquery string
+	}{
+		{"follower_count", "SELECT followee_id FROM follows WHERE follower_id = ?"},
+		{"following_count", "SELECT follower_id FROM follows WHERE followee_id = ?"},
+	}
+	for _, shift := range shifts {
+		query := tx.Unscoped().Model(&models.User{}).Where("id IN ("+shift.subquery+")", userID)
+		if delta < 0 {
+			query = query.Where(shift.column+" >= ?", -delta)
+		}
+		if err := query.UpdateColumn(shift.column, gorm.Expr(shift.column+" + ?", delta)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"gorm.io/gorm"
+)
+
+type gormPostRepository struct {
+	db *gorm.DB
+}
+
+func NewPostRepository(db *gorm.DB) PostRepository {
+	return &gormPostRepository{db: db}
+}
+
+// preloadPost 预加载文章列表和详情需要的关联数据。
+func preloadPost(db *gorm.DB) *gorm.DB {
+	return db.Preload("User").Preload("Category").Preload("Tags")
+}
+
+func postCursorKey(p *models.Post) (time.Time, uint) {
+	return p.CreatedAt, p.ID
+}
+
+// findOrCreateTags 按名称查找标签，不存在的自动创建。
+func findOrCreateTags(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	names = models.NormalizeTagNames(names)
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tag := models.Tag{Name: name}
+		if err := tx.Where("name = ?", name).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+func (r *gormPostRepository) Create(ctx context.Context, post *models.Post, tags []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		found, err := findOrCreateTags(tx, tags)
+		if err != nil {
+			return err
+		}
+		post.Tags = found
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		return adjustCounter(tx, &models.User{}, post.UserID, "post_count", 1)
+	})
+}
+
+func (r *gormPostRepository) FindByID(ctx context.Context, id uint, withComments bool) (*models.Post, error) {
+	query := preloadPost(r.db.WithContext(ctx))
+	if withComments {
+		query = query.Preload("Comments.User")
+	}
+
+	var post models.Post
+	if err := query.First(&post, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &post, nil
+}
+
+func (r *gormPostRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	var posts []models.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	err := preloadPost(r.db.WithContext(ctx)).Where("id IN ?", ids).Find(&posts).Error
+	return posts, err
+}
+
+func (r *gormPostRepository) List(ctx context.Context, filter PostFilter, params *utils.PageParams) (*utils.PageResult[models.Post], error) {
+	db := r.db.WithContext(ctx)
+	query := db.Model(&models.Post{})
+
+	if filter.UserID != 0 {
+		query = query.Where("posts.user_id = ?", filter.UserID)
+	}
+	if len(filter.UserIDs) > 0 {
+		query = query.Where("posts.user_id IN ?", filter.UserIDs)
+	}
+	if filter.Status != "" {
+		query = query.Where("posts.status = ?", filter.Status)
+	}
+	if filter.Tag != "" {
+		query = query.Where("posts.id IN (?)", db.Table("post_tags").
+			Select("post_tags.post_id").
+			Joins("JOIN tags ON tags.id = post_tags.tag_id").
+			Where("tags.name = ?", strings.ToLower(strings.TrimSpace(filter.Tag))))
+	}
+	if filter.Category != "" {
+		query = query.Where("posts.category_id IN (?)", db.Model(&models.Category{}).
+			Select("id").
+			Where("slug = ?", filter.Category))
+	}
+
+	return utils.Paginate(query, params, "posts", postCursorKey, preloadPost)
+}
+
+func (r *gormPostRepository) Update(ctx context.Context, post *models.Post, tags *[]string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 只写入编辑的字段，状态、发布时间、删除标记和冗余计数由专门的语句维护，
+		// 避免用读取时的旧值覆盖并发修改；Save 在没有匹配行时还会插入，使已删除的文章重新出现
+		result := tx.Model(post).
+			Select("title", "content", "publish_at", "category_id", "updated_at").
+			Updates(post)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if tags == nil {
+			return nil
+		}
+		found, err := findOrCreateTags(tx, *tags)
+		if err != nil {
+			return err
+		}
+		post.Tags = found
+		return tx.Model(post).Association("Tags").Replace(found)
+	})
+}
+
+func (r *gormPostRepository) UpdateStatus(ctx context.Context, id uint, from, to string, publishedAt *time.Time) error {
+	updates := map[string]interface{}{"status": to}
+	if publishedAt != nil {
+		updates["published_at"] = *publishedAt
+	}
+	// 按当前状态条件更新，与定时发布等并发修改冲突时不覆盖对方的结果
+	result := r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormPostRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post models.Post
+		if err := tx.Select("id", "user_id").First(&post, id).Error; err != nil {
+			return translateError(err)
+		}
+		// 并发删除时只有真正完成软删除的请求调整计数
+		result := tx.Delete(&models.Post{}, id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return adjustCounter(tx, &models.User{}, post.UserID, "post_count", -1)
+	})
+}
+
+func (r *gormPostRepository) Restore(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post models.Post
+		if err := tx.Unscoped().Select("id", "user_id").Where("deleted_at IS NOT NULL").First(&post, id).Error; err != nil {
+			return translateError(err)
+		}
+		result := tx.Unscoped().Model(&models.Post{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			UpdateColumn("deleted_at", nil)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return adjustCounter(tx, &models.User{}, post.UserID, "post_count", 1)
+	})
+}
+
+func (r *gormPostRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", models.PostStatusDraft, now).
+		Updates(map[string]interface{}{
+			"status":       models.PostStatusPublished,
+			"published_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *gormPostRepository) ListTags(ctx context.Context) ([]models.TagWithCount, error) {
+	var tags []models.TagWithCount
+	err := r.db.WithContext(ctx).Model(&models.Tag{}).
+		Select("tags.id, tags.name, COUNT(posts.id) AS post_count").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.status = ? AND posts.deleted_at IS NULL", models.PostStatusPublished).
+		Group("tags.id, tags.name").
+		Order("post_count DESC, tags.name").
+		Scan(&tags).Error
+	return tags, err
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+type gormReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &gormReactionRepository{db: db}
+}
+
+func (r *gormReactionRepository) Create(ctx context.Context, reaction *models.Reaction) error {
+	err := r.db.WithContext(ctx).Create(reaction).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (r *gormReactionRepository) Delete(ctx context.Context, postID, userID uint, reactionType string) error {
+	result := r.db.WithContext(ctx).
+		Where("post_id = ? AND user_id = ? AND type = ?", postID, userID, reactionType).
+		Delete(&models.Reaction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormReactionRepository) Summaries(ctx context.Context, postIDs []uint, userID uint) (map[uint][]models.ReactionSummary, error) {
+	summaries := make(map[uint][]models.ReactionSummary, len(postIDs))
+	if len(postIDs) == 0 {
+		return summaries, nil
+	}
+	db := r.db.WithContext(ctx)
+
+	var counts []struct {
+		PostID uint
+		Type   string
+		Count  int64
+	}
+	err := db.Model(&models.Reaction{}).
+		Select("post_id, type, COUNT(*) AS count").
+		Where("post_id IN ?", postIDs).
+		Group("post_id, type").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var mine []models.Reaction
+	if userID != 0 {
+		err := db.Select("post_id", "type").
+			Where("user_id = ? AND post_id IN ?", userID, postIDs).
+			Find(&mine).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	countOf := make(map[uint]map[string]int64)
+	for _, c := range counts {
+		if countOf[c.PostID] == nil {
+			countOf[c.PostID] = make(map[string]int64)
+		}
+		countOf[c.PostID][c.Type] = c.Count
+	}
+	reacted := make(map[uint]map[string]bool)
+	for _, m := range mine {
+		if reacted[m.PostID] == nil {
+			reacted[m.PostID] = make(map[string]bool)
+		}
+		reacted[m.PostID][m.Type] = true
+	}
+	for _, id := range postIDs {
+		summaries[id] = models.SummarizeReactions(countOf[id], reacted[id])
+	}
+	return summaries, nil
+}
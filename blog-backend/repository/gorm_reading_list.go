@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+type gormReadingListRepository struct {
+	db *gorm.DB
+}
+
+func NewReadingListRepository(db *gorm.DB) ReadingListRepository {
+	return &gormReadingListRepository{db: db}
+}
+
+func (r *gormReadingListRepository) Create(ctx context.Context, list *models.ReadingList) error {
+	err := r.db.WithContext(ctx).Omit("Items").Create(list).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (r *gormReadingListRepository) FindByID(ctx context.Context, id uint) (*models.ReadingList, error) {
+	var list models.ReadingList
+	if err := r.db.WithContext(ctx).First(&list, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &list, nil
+}
+
+func (r *gormReadingListRepository) FindBySlug(ctx context.Context, slug string) (*models.ReadingList, error) {
+	var list models.ReadingList
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&list).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &list, nil
+}
+
+func (r *gormReadingListRepository) ListByUser(ctx context.Context, userID uint) ([]models.ReadingList, error) {
+	var lists []models.ReadingList
+	err := r.db.WithContext(ctx).
+		Select("reading_lists.*, (SELECT COUNT(*) FROM reading_list_items WHERE reading_list_items.list_id = reading_lists.id) AS item_count").
+		Where("user_id = ?", userID).
+		Order("created_at, id").
+		Find(&lists).Error
+	return lists, err
+}
+
+func (r *gormReadingListRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ReadingList{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *gormReadingListRepository) Update(ctx context.Context, list *models.ReadingList) error {
+	return r.db.WithContext(ctx).Model(list).
+		Select("name", "description", "is_public").
+		Updates(list).Error
+}
+
+func (r *gormReadingListRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("list_id = ?", id).Delete(&models.ReadingListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ReadingList{}, id).Error
+	})
+}
+
+func (r *gormReadingListRepository) Items(ctx context.Context, listID, viewerID uint) ([]models.ReadingListItem, error) {
+	query := r.db.WithContext(ctx).
+		Joins("JOIN posts ON posts.id = reading_list_items.post_id AND posts.deleted_at IS NULL").
+		Where("reading_list_items.list_id = ?", listID)
+	if viewerID == 0 {
+		query = query.Where("posts.status = ?", models.PostStatusPublished)
+	} else {
+		query = query.Where("(posts.status = ? OR posts.user_id = ?)", models.PostStatusPublished, viewerID)
+	}
+
+	items := []models.ReadingListItem{}
+	err := query.Scopes(preloadItemPost).
+		Order("reading_list_items.position, reading_list_items.id").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *gormReadingListRepository) CountItems(ctx context.Context, listID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ReadingListItem{}).Where("list_id = ?", listID).Count(&count).Error
+	return count, err
+}
+
+func (r *gormReadingListRepository) AddItem(ctx context.Context, item *models.ReadingListItem) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last struct{ Position int }
+		err := tx.Model(&models.ReadingListItem{}).
+			Select("COALESCE(MAX(position), 0) AS position").
+			Where("list_id = ?", item.ListID).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		item.Position = last.Position + 1
+		return tx.Omit("Post").Create(item).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+func (r *gormReadingListRepository) RemoveItem(ctx context.Context, listID, postID uint) error {
+	result := r.db.WithContext(ctx).Where("list_id = ? AND post_id = ?", listID, postID).Delete(&models.ReadingListItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormReadingListRepository) Reorder(ctx context.Context, listID uint, postIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var items []models.ReadingListItem
+		err := tx.Select("id", "post_id").
+			Where("list_id = ?", listID).
+			Order("position, id").
+			Find(&items).Error
+		if err != nil {
+			return err
+		}
+
+		order, err := reorderItems(items, postIDs)
+		if err != nil {
+			return err
+		}
+		for i, id := range order {
+			err := tx.Model(&models.ReadingListItem{}).Where("id = ?", id).UpdateColumn("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// reorderItems 返回新顺序下的条目 ID：postIDs 对应的条目在前，其余条目保持原有顺序。
+func reorderItems(items []models.ReadingListItem, postIDs []uint) ([]uint, error) {
+	byPost := make(map[uint]uint, len(items))
+	for _, item := range items {
+		byPost[item.PostID] = item.ID
+	}
+
+	order := make([]uint, 0, len(items))
+	placed := make(map[uint]bool, len(postIDs))
+	for _, postID := range postIDs {
+		id, ok := byPost[postID]
+		if !ok {
+			return nil, ErrNotFound
+		}
+		if !placed[postID] {
+			order = append(order, id)
+			placed[postID] = true
+		}
+	}
+	for _, item := range items {
+		if !placed[item.PostID] {
+			order = append(order, item.ID)
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+type gormSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &gormSessionRepository{db: db}
+}
+
+func (r *gormSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *gormSessionRepository) FindByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.WithContext(ctx).Where("refresh_token_hash = ?", hash).First(&session).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &session, nil
+}
+
+func (r *gormSessionRepository) FindByAccessTokenID(ctx context.Context, tokenID string) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.WithContext(ctx).Where("access_token_id = ?", tokenID).First(&session).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &session, nil
+}
+
+func (r *gormSessionRepository) Rotate(ctx context.Context, oldID uint, next *models.Session) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 条件更新保证并发刷新时只有一个请求能完成轮换
+		result := tx.Model(&models.Session{}).
+			Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", oldID).
+			Update("rotated_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyRotated
+		}
+		return tx.Create(next).Error
+	})
+}
+
+func (r *gormSessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *gormSessionRepository) RevokeUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *gormSessionRepository) RevokeUserExcept(ctx context.Context, userID uint, familyID string) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", time.Now()).Error
+}
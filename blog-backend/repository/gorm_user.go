@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// adjustCounter 在事务中增减冗余计数列，不会把计数减为负数。
+// 使用 Unscoped，已软删除的记录同样维护计数，恢复后无需重新统计。
+func adjustCounter(tx *gorm.DB, model interface{}, id uint, column string, delta int64) error {
+	query := tx.Unscoped().Model(model).Where("id = ?", id)
+	if delta < 0 {
+		query = query.Where(column+" >= ?", -delta)
+	}
+	return query.UpdateColumn(column, gorm.Expr(column+" + ?", delta)).Error
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &gormUserRepository{db: db}
+}
+
+func (r *gormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+func (r *gormUserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepository) ExistsByEmailOrUsername(ctx context.Context, email, username string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("email = ? OR username = ?", email, username).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormUserRepository) List(ctx context.Context, role string) ([]models.User, error) {
+	var users []models.User
+	query := r.db.WithContext(ctx).Order("id")
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	err := query.Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) UpdateRole(ctx context.Context, id uint, role string) error {
+	return r.db.WithContext(ctx).Model(&models.User{ID: id}).Update("role", role).Error
+}
+
+func (r *gormUserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	return r.db.WithContext(ctx).Model(&models.User{ID: id}).Update("password", passwordHash).Error
+}
+
+func (r *gormUserRepository) MarkEmailVerified(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.User{ID: id}).Update("email_verified_at", at).Error
+}
+
+func (r *gormUserRepository) UpdateProfile(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Model(user).Select("nickname", "avatar", "bio").Updates(user).Error
+}
+
+// SetActive 只在状态真正变化时调整关注计数，重复停用或启用不会重复计算。
+func (r *gormUserRepository) SetActive(ctx context.Context, id uint, active bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Where("id = ? AND is_active <> ?", id, active).Update("is_active", active)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if active {
+			return shiftFollowCounts(tx, id, 1)
+		}
+		return shiftFollowCounts(tx, id, -1)
+	})
+}
+
+// Delete 软删除用户并改写用户名和邮箱，释放唯一索引；已停用的用户在停用时已经调整过关注计数。
+func (r *gormUserRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		err := tx.Select("id", "is_active").First(&user, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		username, email := models.DeletedIdentity(id)
+		if err := tx.Model(&user).Updates(map[string]interface{}{"username": username, "email": email}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.User{}, id)
+		if result.Error != nil || result.RowsAffected == 0 || !user.IsActive {
+			return result.Error
+		}
+		return shiftFollowCounts(tx, id, -1)
+	})
+}
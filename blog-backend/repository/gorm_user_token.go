@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+type gormUserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &gormUserTokenRepository{db: db}
+}
+
+func (r *gormUserTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *gormUserTokenRepository) Consume(ctx context.Context, hash, purpose string, now time.Time) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hash = ? AND purpose = ?", hash, purpose).First(&token).Error; err != nil {
+			return translateError(err)
+		}
+		if token.UsedAt != nil || !now.Before(token.ExpiresAt) {
+			return ErrNotFound
+		}
+		// 条件更新保证并发使用同一令牌时只有一个请求成功
+		result := tx.Model(&models.UserToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		token.UsedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *gormUserTokenRepository) Invalidate(ctx context.Context, userID uint, purpose string) error {
+	return r.db.WithContext(ctx).Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormViewRepository struct {
+	db *gorm.DB
+}
+
+func NewViewRepository(db *gorm.DB) ViewRepository {
+	return &gormViewRepository{db: db}
+}
+
+func (r *gormViewRepository) AddViews(ctx context.Context, deltas []ViewDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	// 按固定顺序加锁，避免多个实例同时写入时死锁
+	sorted := append([]ViewDelta(nil), deltas...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].PostID != sorted[j].PostID {
+			return sorted[i].PostID < sorted[j].PostID
+		}
+		return sorted[i].Bucket.Before(sorted[j].Bucket)
+	})
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		totals := make(map[uint]int64)
+		for _, d := range sorted {
+			totals[d.PostID] += d.Views
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "post_id"}, {Name: "bucket"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("post_view_stats.views + ?", d.Views)}),
+			}).Create(&models.PostViewStat{PostID: d.PostID, Bucket: d.Bucket, Views: d.Views}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		ids := make([]uint, 0, len(totals))
+		for id := range totals {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			if err := adjustCounter(tx, &models.Post{}, id, "view_count", totals[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Trending 在数据库中完成衰减计分和排序，只返回 Limit 行。
+func (r *gormViewRepository) Trending(ctx context.Context, q TrendingQuery) ([]PostTrend, error) {
+	dialect := r.db.Dialector.Name()
+	query := fmt.Sprintf(`SELECT activity.post_id AS post_id,
+	SUM(POWER(2, -(CASE WHEN age > 0 THEN age ELSE 0 END) / ?) * (views + ? * comments)) AS score,
+	SUM(views) AS views, SUM(comments) AS comments
+FROM (
+	SELECT post_id, views, 0 AS comments, %s AS age FROM post_view_stats WHERE bucket >= ?
+	UNION ALL
+	SELECT post_id, 0 AS views, 1 AS comments, %s AS age FROM comments WHERE deleted_at IS NULL AND created_at >= ?
+) activity
+JOIN posts ON posts.id = activity.post_id
+WHERE posts.status = ? AND posts.deleted_at IS NULL
+GROUP BY activity.post_id
+ORDER BY score DESC, activity.post_id DESC
+LIMIT ?`, ageSeconds(dialect, "bucket"), ageSeconds(dialect, "created_at"))
+
+	var trends []PostTrend
+	err := r.db.WithContext(ctx).Raw(query,
+		q.HalfLife.Seconds(), q.CommentWeight,
+		q.Now, q.Since,
+		q.Now, q.Since,
+		models.PostStatusPublished, q.Limit,
+	).Scan(&trends).Error
+	return trends, err
+}
+
+// ageSeconds 返回 column 到参数时间相隔的秒数，各数据库的时间运算语法不同。
+func ageSeconds(dialect, column string) string {
+	switch dialect {
+	case "mysql":
+		return fmt.Sprintf("TIMESTAMPDIFF(MICROSECOND, %s, ?) / 1000000", column)
+	case "postgres":
+		return fmt.Sprintf("EXTRACT(EPOCH FROM (CAST(? AS TIMESTAMPTZ) - %s))", column)
+	default:
+		return fmt.Sprintf("(julianday(?) - julianday(%s)) * 86400", column)
+	}
+}
+
+func (r *gormViewRepository) PruneStats(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("bucket < ?", before).Delete(&models.PostViewStat{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type BookmarkRepository struct {
+	s *Store
+}
+
+var _ repository.BookmarkRepository = (*BookmarkRepository)(nil)
+
+func bookmarkField(b *models.Bookmark, field string) sortValue {
+	return sortValue{t: b.CreatedAt}
+}
+
+func bookmarkKey(b *models.Bookmark) (time.Time, uint) {
+	return b.CreatedAt, b.ID
+}
+
+func (r *BookmarkRepository) Create(ctx context.Context, bookmark *models.Bookmark) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.bookmarks {
+		if existing.UserID == bookmark.UserID && existing.PostID == bookmark.PostID {
+			return repository.ErrDuplicate
+		}
+	}
+	bookmark.ID = r.s.id()
+	bookmark.CreatedAt = time.Now()
+	r.s.bookmarks[bookmark.ID] = *bookmark
+	return nil
+}
+
+func (r *BookmarkRepository) Delete(ctx context.Context, userID, postID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, existing := range r.s.bookmarks {
+		if existing.UserID == userID && existing.PostID == postID {
+			delete(r.s.bookmarks, id)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *BookmarkRepository) List(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Bookmark], error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	posts := &PostRepository{r.s}
+	var bookmarks []models.Bookmark
+	for _, bookmark := range r.s.bookmarks {
+		post, ok := r.s.posts[bookmark.PostID]
+		if bookmark.UserID != userID || !ok || post.DeletedAt.Valid || !post.IsPublished() {
+			continue
+		}
+		bookmark.Post = posts.load(post)
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return paginate(bookmarks, params, bookmarkField, bookmarkKey), nil
+}
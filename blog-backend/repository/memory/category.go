@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type CategoryRepository struct {
+	s *Store
+}
+
+var _ repository.CategoryRepository = (*CategoryRepository)(nil)
+
+func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	categories := make([]models.Category, 0, len(r.s.categories))
+	for _, category := range r.s.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	category, ok := r.s.categories[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepository) ExistsByNameOrSlug(ctx context.Context, name, slug string, excludeID uint) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for id, category := range r.s.categories {
+		if id != excludeID && (category.Name == name || category.Slug == slug) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	category.ID = r.s.id()
+	category.CreatedAt, category.UpdatedAt = now, now
+	r.s.categories[category.ID] = *category
+	return nil
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.categories[category.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	category.UpdatedAt = time.Now()
+	r.s.categories[category.ID] = *category
+	return nil
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for postID, post := range r.s.posts {
+		if post.CategoryID != nil && *post.CategoryID == id {
+			post.CategoryID = nil
+			r.s.posts[postID] = post
+		}
+	}
+	delete(r.s.categories, id)
+	return nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type CommentRepository struct {
+	s *Store
+}
+
+var _ repository.CommentRepository = (*CommentRepository)(nil)
+
+func commentField(cm *models.Comment, field string) sortValue {
+	if field == "updated_at" {
+		return sortValue{t: cm.UpdatedAt}
+	}
+	return sortValue{t: cm.CreatedAt}
+}
+
+func commentKey(cm *models.Comment) (time.Time, uint) {
+	return cm.CreatedAt, cm.ID
+}
+
+func (r *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	comment.ID = r.s.id()
+	comment.CreatedAt, comment.UpdatedAt = now, now
+	r.s.comments[comment.ID] = *comment
+	r.s.adjustCommentCount(comment.PostID, 1)
+	comment.User = r.s.users[comment.UserID]
+	return nil
+}
+
+func (r *CommentRepository) FindByID(ctx context.Context, postID, id uint) (*models.Comment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	comment, ok := r.s.comments[id]
+	if !ok || comment.DeletedAt.Valid || comment.PostID != postID {
+		return nil, repository.ErrNotFound
+	}
+	comment.User = r.s.users[comment.UserID]
+	return &comment, nil
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.comments[comment.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	stored.Content = comment.Content
+	stored.UpdatedAt = time.Now()
+	r.s.comments[comment.ID] = stored
+	comment.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+func (r *CommentRepository) Delete(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	comment, ok := r.s.comments[id]
+	if !ok || comment.DeletedAt.Valid {
+		return repository.ErrNotFound
+	}
+	softDelete(&comment.DeletedAt)
+	r.s.comments[id] = comment
+	r.s.adjustCommentCount(comment.PostID, -1)
+	return nil
+}
+
+func (r *CommentRepository) Restore(ctx context.Context, postID, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	comment, ok := r.s.comments[id]
+	if !ok || comment.PostID != postID || !comment.DeletedAt.Valid {
+		return repository.ErrNotFound
+	}
+	restore(&comment.DeletedAt)
+	r.s.comments[id] = comment
+	r.s.adjustCommentCount(postID, 1)
+	return nil
+}
+
+func (r *CommentRepository) List(ctx context.Context, postID uint, params *utils.PageParams) (*utils.PageResult[models.Comment], error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var comments []models.Comment
+	for _, comment := range r.s.comments {
+		if comment.PostID == postID && !comment.DeletedAt.Valid {
+			comment.User = r.s.users[comment.UserID]
+			comments = append(comments, comment)
+		}
+	}
+	return paginate(comments, params, commentField, commentKey), nil
+}
+
+func (r *CommentRepository) ListThread(ctx context.Context, postID uint) ([]models.Comment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	comments := []models.Comment{}
+	for _, comment := range r.s.comments {
+		if comment.PostID == postID {
+			comment.User = r.s.users[comment.UserID]
+			comments = append(comments, comment)
+		}
+	}
+	// ID 单调递增，按 ID 排序即按创建时间排序
+	sortByID(comments, func(c *models.Comment) uint { return c.ID })
+	return comments, nil
+}
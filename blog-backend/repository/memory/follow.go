@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type FollowRepository struct {
+	s *Store
+}
+
+var _ repository.FollowRepository = (*FollowRepository)(nil)
+
+func followField(f *models.Follow, field string) sortValue {
+	return sortValue{t: f.CreatedAt}
+}
+
+func followKey(f *models.Follow) (time.Time, uint) {
+	return f.CreatedAt, f.ID
+}
+
+func (r *FollowRepository) Create(ctx context.Context, follow *models.Follow) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.follows {
+		if existing.FollowerID == follow.FollowerID && existing.FolloweeID == follow.FolloweeID {
+			return repository.ErrDuplicate
+		}
+	}
+	follow.ID = r.s.id()
+	follow.CreatedAt = time.Now()
+	r.s.follows[follow.ID] = *follow
+	r.s.adjustFollowCounts(follow.FollowerID, follow.FolloweeID, 1)
+	return nil
+}
+
+func (r *FollowRepository) Delete(ctx context.Context, followerID, followeeID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, existing := range r.s.follows {
+		if existing.FollowerID == followerID && existing.FolloweeID == followeeID {
+			delete(r.s.follows, id)
+			r.s.adjustFollowCounts(followerID, followeeID, -1)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *FollowRepository) Followers(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Follow], error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var follows []models.Follow
+	for _, follow := range r.s.follows {
+		user, ok := r.s.users[follow.FollowerID]
+		if follow.FolloweeID != userID || !ok || user.DeletedAt.Valid || !user.IsActive {
+			continue
+		}
+		follow.Follower = user
+		follows = append(follows, follow)
+	}
+	return paginate(follows, params, followField, followKey), nil
+}
+
+func (r *FollowRepository) Following(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Follow], error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var follows []models.Follow
+	for _, follow := range r.s.follows {
+		user, ok := r.s.users[follow.FolloweeID]
+		if follow.FollowerID != userID || !ok || user.DeletedAt.Valid || !user.IsActive {
+			continue
+		}
+		follow.Followee = user
+		follows = append(follows, follow)
+	}
+	return paginate(follows, params, followField, followKey), nil
+}
+
+func (r *FollowRepository) FolloweeIDs(ctx context.Context, followerID uint) ([]uint, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var ids []uint
+	for _, follow := range r.s.follows {
+		user, ok := r.s.users[follow.FolloweeID]
+		if follow.FollowerID == followerID && ok && !user.DeletedAt.Valid && user.IsActive {
+			ids = append(ids, follow.FolloweeID)
+		}
+	}
+	return ids, nil
+}
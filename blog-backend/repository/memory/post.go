@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type PostRepository struct {
+	s *Store
+}
+
+var _ repository.PostRepository = (*PostRepository)(nil)
+
+func postField(p *models.Post, field string) sortValue {
+	switch field {
+	case "title":
+		return sortValue{s: p.Title}
+	case "updated_at":
+		return sortValue{t: p.UpdatedAt}
+	case "published_at":
+		if p.PublishedAt != nil {
+			return sortValue{t: *p.PublishedAt}
+		}
+		return sortValue{}
+	default:
+		return sortValue{t: p.CreatedAt}
+	}
+}
+
+func postKey(p *models.Post) (time.Time, uint) {
+	return p.CreatedAt, p.ID
+}
+
+// tagIDs 按名称查找标签，不存在的自动创建，调用方需持有写锁。
+func (r *PostRepository) tagIDs(names []string) []uint {
+	names = models.NormalizeTagNames(names)
+	ids := make([]uint, 0, len(names))
+	for _, name := range names {
+		var found uint
+		for id, tag := range r.s.tags {
+			if tag.Name == name {
+				found = id
+				break
+			}
+		}
+		if found == 0 {
+			found = r.s.id()
+			r.s.tags[found] = models.Tag{ID: found, Name: name, CreatedAt: time.Now()}
+		}
+		ids = append(ids, found)
+	}
+	return ids
+}
+
+// load 填充文章的作者、分类和标签，调用方需持有读锁。
+func (r *PostRepository) load(post models.Post) models.Post {
+	post.User = r.s.users[post.UserID]
+	post.Category = nil
+	if post.CategoryID != nil {
+		if category, ok := r.s.categories[*post.CategoryID]; ok {
+			post.Category = &category
+		}
+	}
+	post.Tags = []models.Tag{}
+	for _, id := range r.s.postTags[post.ID] {
+		post.Tags = append(post.Tags, r.s.tags[id])
+	}
+	post.Comments = nil
+	return post
+}
+
+func (r *PostRepository) Create(ctx context.Context, post *models.Post, tags []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	post.ID = r.s.id()
+	post.CreatedAt, post.UpdatedAt = now, now
+	if post.Status == "" {
+		post.Status = models.PostStatusDraft
+	}
+	r.s.postTags[post.ID] = r.tagIDs(tags)
+	r.s.posts[post.ID] = *post
+	r.s.adjustPostCount(post.UserID, 1)
+	*post = r.load(*post)
+	return nil
+}
+
+func (r *PostRepository) FindByID(ctx context.Context, id uint, withComments bool) (*models.Post, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	post, ok := r.s.posts[id]
+	if !ok || post.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	post = r.load(post)
+	if withComments {
+		for _, comment := range r.s.comments {
+			if comment.PostID == id && !comment.DeletedAt.Valid {
+				comment.User = r.s.users[comment.UserID]
+				post.Comments = append(post.Comments, comment)
+			}
+		}
+		sortByID(post.Comments, func(c *models.Comment) uint { return c.ID })
+	}
+	return &post, nil
+}
+
+func (r *PostRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var posts []models.Post
+	for _, id := range ids {
+		if post, ok := r.s.posts[id]; ok && !post.DeletedAt.Valid {
+			posts = append(posts, r.load(post))
+		}
+	}
+	return posts, nil
+}
+
+func (r *PostRepository) hasTag(postID uint, name string) bool {
+	for _, id := range r.s.postTags[postID] {
+		if r.s.tags[id].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *PostRepository) List(ctx context.Context, filter repository.PostFilter, params *utils.PageParams) (*utils.PageResult[models.Post], error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	tag := normalizeTag(filter.Tag)
+	var posts []models.Post
+	for _, post := range r.s.posts {
+		if post.DeletedAt.Valid ||
+			(filter.UserID != 0 && post.UserID != filter.UserID) ||
+			(len(filter.UserIDs) > 0 && !slices.Contains(filter.UserIDs, post.UserID)) ||
+			(filter.Status != "" && post.Status != filter.Status) ||
+			(tag != "" && !r.hasTag(post.ID, tag)) {
+			continue
+		}
+		if filter.Category != "" {
+			if post.CategoryID == nil || r.s.categories[*post.CategoryID].Slug != filter.Category {
+				continue
+			}
+		}
+		posts = append(posts, r.load(post))
+	}
+	return paginate(posts, params, postField, postKey), nil
+}
+
+func (r *PostRepository) Update(ctx context.Context, post *models.Post, tags *[]string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.posts[post.ID]
+	if !ok || stored.DeletedAt.Valid {
+		return repository.ErrNotFound
+	}
+	stored.Title, stored.Content = post.Title, post.Content
+	stored.PublishAt, stored.CategoryID = post.PublishAt, post.CategoryID
+	stored.UpdatedAt = time.Now()
+	if tags != nil {
+		r.s.postTags[post.ID] = r.tagIDs(*tags)
+	}
+	r.s.posts[post.ID] = stored
+	*post = r.load(stored)
+	return nil
+}
+
+func (r *PostRepository) UpdateStatus(ctx context.Context, id uint, from, to string, publishedAt *time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	post, ok := r.s.posts[id]
+	if !ok || post.DeletedAt.Valid || post.Status != from {
+		return repository.ErrNotFound
+	}
+	post.Status = to
+	if publishedAt != nil {
+		post.PublishedAt = publishedAt
+	}
+	post.UpdatedAt = time.Now()
+	r.s.posts[id] = post
+	return nil
+}
+
+func (r *PostRepository) Delete(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	post, ok := r.s.posts[id]
+	if !ok || post.DeletedAt.Valid {
+		return repository.ErrNotFound
+	}
+	softDelete(&post.DeletedAt)
+	r.s.posts[id] = post
+	r.s.adjustPostCount(post.UserID, -1)
+	return nil
+}
+
+func (r *PostRepository) Restore(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	post, ok := r.s.posts[id]
+	if !ok || !post.DeletedAt.Valid {
+		return repository.ErrNotFound
+	}
+	restore(&post.DeletedAt)
+	r.s.posts[id] = post
+	r.s.adjustPostCount(post.UserID, 1)
+	return nil
+}
+
+func (r *PostRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var count int64
+	for id, post := range r.s.posts {
+		if post.DeletedAt.Valid || post.Status != models.PostStatusDraft ||
+			post.PublishAt == nil || post.PublishAt.After(now) {
+			continue
+		}
+		publishedAt := now
+		post.Status = models.PostStatusPublished
+		post.PublishedAt = &publishedAt
+		r.s.posts[id] = post
+		count++
+	}
+	return count, nil
+}
+
+func (r *PostRepository) ListTags(ctx context.Context) ([]models.TagWithCount, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	counts := make(map[uint]int64, len(r.s.tags))
+	for postID, tagIDs := range r.s.postTags {
+		post, ok := r.s.posts[postID]
+		if !ok || post.DeletedAt.Valid || !post.IsPublished() {
+			continue
+		}
+		for _, id := range tagIDs {
+			counts[id]++
+		}
+	}
+
+	tags := make([]models.TagWithCount, 0, len(r.s.tags))
+	for id, tag := range r.s.tags {
+		tags = append(tags, models.TagWithCount{ID: id, Name: tag.Name, PostCount: counts[id]})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].PostCount != tags[j].PostCount {
+			return tags[i].PostCount > tags[j].PostCount
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
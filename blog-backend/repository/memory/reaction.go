@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type ReactionRepository struct {
+	s *Store
+}
+
+var _ repository.ReactionRepository = (*ReactionRepository)(nil)
+
+func (r *ReactionRepository) Create(ctx context.Context, reaction *models.Reaction) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.reactions {
+		if existing.PostID == reaction.PostID && existing.UserID == reaction.UserID && existing.Type == reaction.Type {
+			return repository.ErrDuplicate
+		}
+	}
+	reaction.ID = r.s.id()
+	reaction.CreatedAt = time.Now()
+	r.s.reactions[reaction.ID] = *reaction
+	return nil
+}
+
+func (r *ReactionRepository) Delete(ctx context.Context, postID, userID uint, reactionType string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, existing := range r.s.reactions {
+		if existing.PostID == postID && existing.UserID == userID && existing.Type == reactionType {
+			delete(r.s.reactions, id)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *ReactionRepository) Summaries(ctx context.Context, postIDs []uint, userID uint) (map[uint][]models.ReactionSummary, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	summaries := make(map[uint][]models.ReactionSummary, len(postIDs))
+	for _, postID := range postIDs {
+		counts := make(map[string]int64)
+		reacted := make(map[string]bool)
+		for _, reaction := range r.s.reactions {
+			if reaction.PostID != postID {
+				continue
+			}
+			counts[reaction.Type]++
+			if userID != 0 && reaction.UserID == userID {
+				reacted[reaction.Type] = true
+			}
+		}
+		summaries[postID] = models.SummarizeReactions(counts, reacted)
+	}
+	return summaries, nil
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type ReadingListRepository struct {
+	s *Store
+}
+
+var _ repository.ReadingListRepository = (*ReadingListRepository)(nil)
+
+func (r *ReadingListRepository) Create(ctx context.Context, list *models.ReadingList) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.lists {
+		if existing.Slug == list.Slug {
+			return repository.ErrDuplicate
+		}
+	}
+	now := time.Now()
+	list.ID = r.s.id()
+	list.CreatedAt, list.UpdatedAt = now, now
+	r.s.lists[list.ID] = *list
+	return nil
+}
+
+func (r *ReadingListRepository) FindByID(ctx context.Context, id uint) (*models.ReadingList, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	list, ok := r.s.lists[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &list, nil
+}
+
+func (r *ReadingListRepository) FindBySlug(ctx context.Context, slug string) (*models.ReadingList, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, list := range r.s.lists {
+		if list.Slug == slug {
+			return &list, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *ReadingListRepository) ListByUser(ctx context.Context, userID uint) ([]models.ReadingList, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	lists := []models.ReadingList{}
+	for _, list := range r.s.lists {
+		if list.UserID == userID {
+			list.ItemCount = int64(len(r.itemsOf(list.ID)))
+			lists = append(lists, list)
+		}
+	}
+	sortByID(lists, func(l *models.ReadingList) uint { return l.ID })
+	return lists, nil
+}
+
+func (r *ReadingListRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var n int64
+	for _, list := range r.s.lists {
+		if list.UserID == userID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *ReadingListRepository) Update(ctx context.Context, list *models.ReadingList) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.lists[list.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	stored.Name, stored.Description, stored.IsPublic = list.Name, list.Description, list.IsPublic
+	stored.UpdatedAt = time.Now()
+	r.s.lists[list.ID] = stored
+	return nil
+}
+
+func (r *ReadingListRepository) Delete(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, item := range r.itemsOf(id) {
+		delete(r.s.listItems, item.ID)
+	}
+	delete(r.s.lists, id)
+	return nil
+}
+
+func (r *ReadingListRepository) Items(ctx context.Context, listID, viewerID uint) ([]models.ReadingListItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	posts := &PostRepository{r.s}
+	items := []models.ReadingListItem{}
+	for _, item := range r.itemsOf(listID) {
+		post, ok := r.s.posts[item.PostID]
+		if !ok || post.DeletedAt.Valid || (!post.IsPublished() && (viewerID == 0 || post.UserID != viewerID)) {
+			continue
+		}
+		item.Post = posts.load(post)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *ReadingListRepository) CountItems(ctx context.Context, listID uint) (int64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return int64(len(r.itemsOf(listID))), nil
+}
+
+func (r *ReadingListRepository) AddItem(ctx context.Context, item *models.ReadingListItem) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	position := 0
+	for _, existing := range r.itemsOf(item.ListID) {
+		if existing.PostID == item.PostID {
+			return repository.ErrDuplicate
+		}
+		position = max(position, existing.Position)
+	}
+	item.ID = r.s.id()
+	item.Position = position + 1
+	item.CreatedAt = time.Now()
+	r.s.listItems[item.ID] = *item
+	return nil
+}
+
+func (r *ReadingListRepository) RemoveItem(ctx context.Context, listID, postID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, item := range r.itemsOf(listID) {
+		if item.PostID == postID {
+			delete(r.s.listItems, item.ID)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *ReadingListRepository) Reorder(ctx context.Context, listID uint, postIDs []uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	items := r.itemsOf(listID)
+	rank := make(map[uint]int, len(postIDs))
+	for i, postID := range postIDs {
+		rank[postID] = i + 1
+	}
+	found := 0
+	for _, item := range items {
+		if rank[item.PostID] > 0 {
+			found++
+		}
+	}
+	if found != len(rank) {
+		return repository.ErrNotFound
+	}
+
+	// 列出的文章按给定顺序在前，其余保持原有顺序
+	sort.SliceStable(items, func(i, j int) bool {
+		ri, rj := rank[items[i].PostID], rank[items[j].PostID]
+		switch {
+		case ri > 0 && rj > 0:
+			return ri < rj
+		default:
+			return ri > 0 && rj == 0
+		}
+	})
+	for i, item := range items {
+		item.Position = i + 1
+		r.s.listItems[item.ID] = item
+	}
+	return nil
+}
+
+// itemsOf 按位置返回列表的所有条目，调用方需持有锁。
+func (r *ReadingListRepository) itemsOf(listID uint) []models.ReadingListItem {
+	var items []models.ReadingListItem
+	for _, item := range r.s.listItems {
+		if item.ListID == listID {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
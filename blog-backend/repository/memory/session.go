@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type SessionRepository struct {
+	s *Store
+}
+
+var _ repository.SessionRepository = (*SessionRepository)(nil)
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.createLocked(session)
+	return nil
+}
+
+func (r *SessionRepository) createLocked(session *models.Session) {
+	now := time.Now()
+	session.ID = r.s.id()
+	session.CreatedAt, session.UpdatedAt = now, now
+	r.s.sessions[session.ID] = *session
+}
+
+func (r *SessionRepository) find(match func(*models.Session) bool) (*models.Session, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, session := range r.s.sessions {
+		if match(&session) {
+			return &session, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *SessionRepository) FindByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	return r.find(func(s *models.Session) bool { return s.RefreshTokenHash == hash })
+}
+
+func (r *SessionRepository) FindByAccessTokenID(ctx context.Context, tokenID string) (*models.Session, error) {
+	return r.find(func(s *models.Session) bool { return s.AccessTokenID == tokenID })
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, oldID uint, next *models.Session) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	old, ok := r.s.sessions[oldID]
+	if !ok || old.RotatedAt != nil || old.RevokedAt != nil {
+		return repository.ErrAlreadyRotated
+	}
+	now := time.Now()
+	old.RotatedAt = &now
+	r.s.sessions[oldID] = old
+	r.createLocked(next)
+	return nil
+}
+
+func (r *SessionRepository) revoke(match func(*models.Session) bool) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for id, session := range r.s.sessions {
+		if session.RevokedAt == nil && match(&session) {
+			session.RevokedAt = &now
+			r.s.sessions[id] = session
+		}
+	}
+}
+
+func (r *SessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	r.revoke(func(s *models.Session) bool { return s.FamilyID == familyID })
+	return nil
+}
+
+func (r *SessionRepository) RevokeUser(ctx context.Context, userID uint) error {
+	r.revoke(func(s *models.Session) bool { return s.UserID == userID })
+	return nil
+}
+
+func (r *SessionRepository) RevokeUserExcept(ctx context.Context, userID uint, familyID string) error {
+	r.revoke(func(s *models.Session) bool { return s.UserID == userID && s.FamilyID != familyID })
+	return nil
+}
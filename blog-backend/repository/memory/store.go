@@ -0,0 +1,204 @@
+// Package memory 提供 repository 接口的内存实现，用于单元测试，不依赖数据库。
+package memory
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"gorm.io/gorm"
+)
+
+// Store 保存所有内存数据，各个 repository 共享同一个 Store 以便关联查询。
+type Store struct {
+	mu sync.RWMutex
+
+	nextID     uint
+	users      map[uint]models.User
+	sessions   map[uint]models.Session
+	userTokens map[uint]models.UserToken
+	posts      map[uint]models.Post
+	postTags   map[uint][]uint
+	tags       map[uint]models.Tag
+	comments   map[uint]models.Comment
+	categories map[uint]models.Category
+	viewStats  map[viewStatKey]int64
+	reactions  map[uint]models.Reaction
+	bookmarks  map[uint]models.Bookmark
+	lists      map[uint]models.ReadingList
+	listItems  map[uint]models.ReadingListItem
+	follows    map[uint]models.Follow
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:      make(map[uint]models.User),
+		sessions:   make(map[uint]models.Session),
+		userTokens: make(map[uint]models.UserToken),
+		posts:      make(map[uint]models.Post),
+		postTags:   make(map[uint][]uint),
+		tags:       make(map[uint]models.Tag),
+		comments:   make(map[uint]models.Comment),
+		categories: make(map[uint]models.Category),
+		viewStats:  make(map[viewStatKey]int64),
+		reactions:  make(map[uint]models.Reaction),
+		bookmarks:  make(map[uint]models.Bookmark),
+		lists:      make(map[uint]models.ReadingList),
+		listItems:  make(map[uint]models.ReadingListItem),
+		follows:    make(map[uint]models.Follow),
+	}
+}
+
+func (s *Store) Users() *UserRepository               { return &UserRepository{s} }
+func (s *Store) Sessions() *SessionRepository         { return &SessionRepository{s} }
+func (s *Store) UserTokens() *UserTokenRepository     { return &UserTokenRepository{s} }
+func (s *Store) Posts() *PostRepository               { return &PostRepository{s} }
+func (s *Store) Comments() *CommentRepository         { return &CommentRepository{s} }
+func (s *Store) Categories() *CategoryRepository      { return &CategoryRepository{s} }
+func (s *Store) Views() *ViewRepository               { return &ViewRepository{s} }
+func (s *Store) Reactions() *ReactionRepository       { return &ReactionRepository{s} }
+func (s *Store) Bookmarks() *BookmarkRepository       { return &BookmarkRepository{s} }
+func (s *Store) ReadingLists() *ReadingListRepository { return &ReadingListRepository{s} }
+func (s *Store) Follows() *FollowRepository           { return &FollowRepository{s} }
+
+// id 分配一个全局递增的 ID，调用方需持有写锁。
+func (s *Store) id() uint {
+	s.nextID++
+	return s.nextID
+}
+
+func softDelete(at *gorm.DeletedAt) {
+	*at = gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+func restore(at *gorm.DeletedAt) {
+	*at = gorm.DeletedAt{}
+}
+
+// adjustPostCount 和 adjustCommentCount 维护冗余计数，调用方需持有写锁。
+func (s *Store) adjustPostCount(userID uint, delta int64) {
+	if user, ok := s.users[userID]; ok {
+		user.PostCount = max(user.PostCount+delta, 0)
+		s.users[userID] = user
+	}
+}
+
+func (s *Store) adjustCommentCount(postID uint, delta int64) {
+	if post, ok := s.posts[postID]; ok {
+		post.CommentCount = max(post.CommentCount+delta, 0)
+		s.posts[postID] = post
+	}
+}
+
+// adjustFollowCounts 维护关注者的 following_count 和被关注者的 follower_count，
+// 只计入未删除且未停用的对方，调用方需持有写锁。
+func (s *Store) adjustFollowCounts(followerID, followeeID uint, delta int64) {
+	follower, followerOK := s.users[followerID]
+	followee, followeeOK := s.users[followeeID]
+	if followerOK && followeeOK && visible(&followee) {
+		follower.FollowingCount = max(follower.FollowingCount+delta, 0)
+		s.users[followerID] = follower
+	}
+	if followerOK && followeeOK && visible(&follower) {
+		followee.FollowerCount = max(followee.FollowerCount+delta, 0)
+		s.users[followeeID] = followee
+	}
+}
+
+// shiftFollowCounts 在用户变为不可见或重新可见时调整对方的关注计数，调用方需持有写锁。
+func (s *Store) shiftFollowCounts(userID uint, delta int64) {
+	for _, follow := range s.follows {
+		if follow.FollowerID == userID {
+			if user, ok := s.users[follow.FolloweeID]; ok {
+				user.FollowerCount = max(user.FollowerCount+delta, 0)
+				s.users[follow.FolloweeID] = user
+			}
+		}
+		if follow.FolloweeID == userID {
+			if user, ok := s.users[follow.FollowerID]; ok {
+				user.FollowingCount = max(user.FollowingCount+delta, 0)
+				s.users[follow.FollowerID] = user
+			}
+		}
+	}
+}
+
+func visible(user *models.User) bool {
+	return !user.DeletedAt.Valid && user.IsActive
+}
+
+// sortValue 是用于排序的字段值，字符串字段和时间字段只会设置其中一个。
+type sortValue struct {
+	t time.Time
+	s string
+}
+
+func (a sortValue) less(b sortValue) bool {
+	if a.s != b.s {
+		return a.s < b.s
+	}
+	return a.t.Before(b.t)
+}
+
+// paginate 在内存中实现与 utils.Paginate 相同的排序、游标和偏移语义。
+func paginate[T any](items []T, params *utils.PageParams, field func(*T, string) sortValue, key func(*T) (time.Time, uint)) *utils.PageResult[T] {
+	less := func(a, b *T) bool {
+		fa, fb := field(a, params.SortField), field(b, params.SortField)
+		_, ida := key(a)
+		_, idb := key(b)
+		if fa.less(fb) || fb.less(fa) {
+			if params.SortDesc {
+				return fb.less(fa)
+			}
+			return fa.less(fb)
+		}
+		if params.SortDesc {
+			return ida > idb
+		}
+		return ida < idb
+	}
+	sort.Slice(items, func(i, j int) bool { return less(&items[i], &items[j]) })
+	total := int64(len(items))
+
+	if cur := params.Cursor; cur != nil {
+		filtered := items[:0]
+		for i := range items {
+			createdAt, id := key(&items[i])
+			after := createdAt.After(cur.CreatedAt) || (createdAt.Equal(cur.CreatedAt) && id > cur.ID)
+			before := createdAt.Before(cur.CreatedAt) || (createdAt.Equal(cur.CreatedAt) && id < cur.ID)
+			if (cur.Desc && before) || (!cur.Desc && after) {
+				filtered = append(filtered, items[i])
+			}
+		}
+		items = filtered
+	}
+
+	result := &utils.PageResult[T]{Page: params.Page, Limit: params.Limit, Total: total, Items: []T{}}
+	offset := params.Offset()
+	if offset < len(items) {
+		items = items[offset:]
+	} else {
+		items = nil
+	}
+	if len(items) > params.Limit {
+		result.HasNext = true
+		items = items[:params.Limit]
+		if params.SortField == "created_at" {
+			createdAt, id := key(&items[len(items)-1])
+			result.NextCursor = utils.Cursor{CreatedAt: createdAt, ID: id, Desc: params.SortDesc}.Encode()
+		}
+	}
+	result.Items = append(result.Items, items...)
+	return result
+}
+
+func normalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func sortByID[T any](items []T, id func(*T) uint) {
+	sort.Slice(items, func(i, j int) bool { return id(&items[i]) < id(&items[j]) })
+}
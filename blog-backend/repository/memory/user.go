@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type UserRepository struct {
+	s *Store
+}
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	user.ID = r.s.id()
+	user.CreatedAt, user.UpdatedAt = now, now
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	user, ok := r.s.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, repository.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.Email == email && !user.DeletedAt.Valid {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if user.Username == username && !user.DeletedAt.Valid {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *UserRepository) ExistsByEmailOrUsername(ctx context.Context, email, username string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if !user.DeletedAt.Valid && (user.Email == email || user.Username == username) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *UserRepository) List(ctx context.Context, role string) ([]models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	users := []models.User{}
+	for _, user := range r.s.users {
+		if !user.DeletedAt.Valid && (role == "" || user.Role == role) {
+			users = append(users, user)
+		}
+	}
+	sortByID(users, func(u *models.User) uint { return u.ID })
+	return users, nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uint, role string) error {
+	return r.update(id, func(u *models.User) { u.Role = role })
+}
+
+func (r *UserRepository) update(id uint, apply func(*models.User)) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	apply(&user)
+	user.UpdatedAt = time.Now()
+	r.s.users[id] = user
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	return r.update(id, func(u *models.User) { u.Password = passwordHash })
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id uint, at time.Time) error {
+	return r.update(id, func(u *models.User) { u.EmailVerifiedAt = &at })
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *models.User) error {
+	return r.update(user.ID, func(u *models.User) {
+		u.Nickname, u.Avatar, u.Bio = user.Nickname, user.Avatar, user.Bio
+	})
+}
+
+func (r *UserRepository) SetActive(ctx context.Context, id uint, active bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[id]
+	if !ok || user.DeletedAt.Valid || user.IsActive == active {
+		return nil
+	}
+	user.IsActive = active
+	user.UpdatedAt = time.Now()
+	r.s.users[id] = user
+	if active {
+		r.s.shiftFollowCounts(id, 1)
+	} else {
+		r.s.shiftFollowCounts(id, -1)
+	}
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil
+	}
+	wasVisible := visible(&user)
+	user.Username, user.Email = models.DeletedIdentity(id)
+	softDelete(&user.DeletedAt)
+	r.s.users[id] = user
+	if wasVisible {
+		r.s.shiftFollowCounts(id, -1)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type UserTokenRepository struct {
+	s *Store
+}
+
+var _ repository.UserTokenRepository = (*UserTokenRepository)(nil)
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	token.ID = r.s.id()
+	token.CreatedAt = time.Now()
+	r.s.userTokens[token.ID] = *token
+	return nil
+}
+
+func (r *UserTokenRepository) Consume(ctx context.Context, hash, purpose string, now time.Time) (*models.UserToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, token := range r.s.userTokens {
+		if token.TokenHash != hash || token.Purpose != purpose {
+			continue
+		}
+		if token.UsedAt != nil || !now.Before(token.ExpiresAt) {
+			return nil, repository.ErrNotFound
+		}
+		token.UsedAt = &now
+		r.s.userTokens[id] = token
+		return &token, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *UserTokenRepository) Invalidate(ctx context.Context, userID uint, purpose string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for id, token := range r.s.userTokens {
+		if token.UserID == userID && token.Purpose == purpose && token.UsedAt == nil {
+			token.UsedAt = &now
+			r.s.userTokens[id] = token
+		}
+	}
+	return nil
+}
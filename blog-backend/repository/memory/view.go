@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type viewStatKey struct {
+	postID uint
+	bucket time.Time
+}
+
+type ViewRepository struct {
+	s *Store
+}
+
+var _ repository.ViewRepository = (*ViewRepository)(nil)
+
+func (r *ViewRepository) AddViews(ctx context.Context, deltas []repository.ViewDelta) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, d := range deltas {
+		r.s.viewStats[viewStatKey{d.PostID, d.Bucket}] += d.Views
+		if post, ok := r.s.posts[d.PostID]; ok {
+			post.ViewCount += d.Views
+			r.s.posts[d.PostID] = post
+		}
+	}
+	return nil
+}
+
+func (r *ViewRepository) Trending(ctx context.Context, q repository.TrendingQuery) ([]repository.PostTrend, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	published := func(postID uint) bool {
+		post, ok := r.s.posts[postID]
+		return ok && !post.DeletedAt.Valid && post.Status == models.PostStatusPublished
+	}
+	trends := make(map[uint]*repository.PostTrend)
+	add := func(postID uint, at time.Time, views, comments int64) {
+		if at.Before(q.Since) || !published(postID) {
+			return
+		}
+		trend, ok := trends[postID]
+		if !ok {
+			trend = &repository.PostTrend{PostID: postID}
+			trends[postID] = trend
+		}
+		age := max(q.Now.Sub(at), 0)
+		trend.Score += math.Exp2(-float64(age)/float64(q.HalfLife)) * float64(views+q.CommentWeight*comments)
+		trend.Views += views
+		trend.Comments += comments
+	}
+	for key, views := range r.s.viewStats {
+		add(key.postID, key.bucket, views, 0)
+	}
+	for _, comment := range r.s.comments {
+		if !comment.DeletedAt.Valid {
+			add(comment.PostID, comment.CreatedAt, 0, 1)
+		}
+	}
+
+	ranked := make([]repository.PostTrend, 0, len(trends))
+	for _, trend := range trends {
+		ranked = append(ranked, *trend)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].PostID > ranked[j].PostID
+	})
+	if len(ranked) > q.Limit {
+		ranked = ranked[:q.Limit]
+	}
+	return ranked, nil
+}
+
+func (r *ViewRepository) PruneStats(ctx context.Context, before time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var n int64
+	for key := range r.s.viewStats {
+		if key.bucket.Before(before) {
+			delete(r.s.viewStats, key)
+			n++
+		}
+	}
+	return n, nil
+}
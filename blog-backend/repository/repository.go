@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+var (
+	ErrNotFound       = errors.New("record not found")
+	ErrAlreadyRotated = errors.New("session already rotated")
+	ErrDuplicate      = errors.New("duplicate record")
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	ExistsByEmailOrUsername(ctx context.Context, email, username string) (bool, error)
+	List(ctx context.Context, role string) ([]models.User, error)
+	UpdateRole(ctx context.Context, id uint, role string) error
+	UpdatePassword(ctx context.Context, id uint, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id uint, at time.Time) error
+	// UpdateProfile 保存昵称、头像和简介。
+	UpdateProfile(ctx context.Context, user *models.User) error
+	SetActive(ctx context.Context, id uint, active bool) error
+	// Delete 软删除用户，并把用户名和邮箱改写为 models.DeletedIdentity 以便重新注册。
+	Delete(ctx context.Context, id uint) error
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error)
+	FindByAccessTokenID(ctx context.Context, tokenID string) (*models.Session, error)
+	// Rotate 将 oldID 标记为已轮换并创建 next；oldID 已被轮换或吊销时返回 ErrAlreadyRotated。
+	Rotate(ctx context.Context, oldID uint, next *models.Session) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeUser(ctx context.Context, userID uint) error
+	// RevokeUserExcept 吊销用户除 familyID 之外的所有会话。
+	RevokeUserExcept(ctx context.Context, userID uint, familyID string) error
+}
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *models.UserToken) error
+	// Consume 将令牌标记为已使用并返回；令牌不存在、用途不符、已使用或已过期时返回 ErrNotFound。
+	Consume(ctx context.Context, hash, purpose string, now time.Time) (*models.UserToken, error)
+	// Invalidate 作废用户某一用途下所有未使用的令牌。
+	Invalidate(ctx context.Context, userID uint, purpose string) error
+}
+
+// PostFilter 是文章列表的过滤条件，零值字段表示不过滤。UserIDs 非空时只返回这些作者的文章。
+type PostFilter struct {
+	UserID   uint
+	UserIDs  []uint
+	Status   string
+	Tag      string
+	Category string
+}
+
+type PostRepository interface {
+	// Create 创建文章并按名称关联标签，不存在的标签自动创建，同时增加作者的 post_count。
+	Create(ctx context.Context, post *models.Post, tags []string) error
+	// FindByID 返回文章及作者、分类、标签，withComments 为 true 时同时加载评论。
+	FindByID(ctx context.Context, id uint, withComments bool) (*models.Post, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error)
+	List(ctx context.Context, filter PostFilter, params *utils.PageParams) (*utils.PageResult[models.Post], error)
+	// Update 只保存标题、内容、定时发布时间和分类，tags 为 nil 时不修改标签。
+	// 状态由 UpdateStatus 修改；文章已被删除时返回 ErrNotFound。
+	Update(ctx context.Context, post *models.Post, tags *[]string) error
+	// UpdateStatus 仅在文章当前状态为 from 时改为 to，publishedAt 非 nil 时同时记录发布时间。
+	// 文章已被删除或状态已被并发修改时返回 ErrNotFound。
+	UpdateStatus(ctx context.Context, id uint, from, to string, publishedAt *time.Time) error
+	// Delete 软删除文章并减少作者的 post_count。
+	Delete(ctx context.Context, id uint) error
+	// Restore 恢复已软删除的文章并增加作者的 post_count，文章不存在或未被删除时返回 ErrNotFound。
+	Restore(ctx context.Context, id uint) error
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	ListTags(ctx context.Context) ([]models.TagWithCount, error)
+}
+
+type CommentRepository interface {
+	// Create 创建评论并增加文章的 comment_count。
+	Create(ctx context.Context, comment *models.Comment) error
+	FindByID(ctx context.Context, postID, id uint) (*models.Comment, error)
+	Update(ctx context.Context, comment *models.Comment) error
+	// Delete 软删除评论并减少文章的 comment_count。
+	Delete(ctx context.Context, id uint) error
+	// Restore 恢复文章下已软删除的评论，评论不存在或未被删除时返回 ErrNotFound。
+	Restore(ctx context.Context, postID, id uint) error
+	List(ctx context.Context, postID uint, params *utils.PageParams) (*utils.PageResult[models.Comment], error)
+	// ListThread 按时间顺序返回文章的全部评论，包含软删除的记录。
+	ListThread(ctx context.Context, postID uint) ([]models.Comment, error)
+}
+
+type CategoryRepository interface {
+	List(ctx context.Context) ([]models.Category, error)
+	FindByID(ctx context.Context, id uint) (*models.Category, error)
+	// ExistsByNameOrSlug 判断是否存在同名或同 slug 的其他分类，excludeID 为 0 时不排除。
+	ExistsByNameOrSlug(ctx context.Context, name, slug string, excludeID uint) (bool, error)
+	Create(ctx context.Context, category *models.Category) error
+	Update(ctx context.Context, category *models.Category) error
+	// Delete 删除分类，并把该分类下的文章置为未分类。
+	Delete(ctx context.Context, id uint) error
+}
+
+type ReactionRepository interface {
+	// Create 添加表态，同一用户对同一文章重复留下同一类型时返回 ErrDuplicate。
+	Create(ctx context.Context, reaction *models.Reaction) error
+	// Delete 删除表态，不存在时返回 ErrNotFound。
+	Delete(ctx context.Context, postID, userID uint, reactionType string) error
+	// Summaries 返回每篇文章各类型的表态数量，userID 不为 0 时标记该用户已留下的表态。
+	Summaries(ctx context.Context, postIDs []uint, userID uint) (map[uint][]models.ReactionSummary, error)
+}
+
+type BookmarkRepository interface {
+	// Create 收藏文章，已收藏时返回 ErrDuplicate。
+	Create(ctx context.Context, bookmark *models.Bookmark) error
+	// Delete 取消收藏，未收藏时返回 ErrNotFound。
+	Delete(ctx context.Context, userID, postID uint) error
+	// List 按页返回用户收藏的已发布且未删除的文章。
+	List(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Bookmark], error)
+}
+
+type ReadingListRepository interface {
+	// Create 创建列表，Slug 已存在时返回 ErrDuplicate。
+	Create(ctx context.Context, list *models.ReadingList) error
+	FindByID(ctx context.Context, id uint) (*models.ReadingList, error)
+	FindBySlug(ctx context.Context, slug string) (*models.ReadingList, error)
+	// ListByUser 返回用户的所有列表并填充 ItemCount。
+	ListByUser(ctx context.Context, userID uint) ([]models.ReadingList, error)
+	CountByUser(ctx context.Context, userID uint) (int64, error)
+	// Update 保存名称、描述和是否公开。
+	Update(ctx context.Context, list *models.ReadingList) error
+	// Delete 删除列表及其中的条目。
+	Delete(ctx context.Context, id uint) error
+	// Items 按位置返回列表中未删除的文章，viewerID 为 0 时只返回已发布的文章，否则还包括该用户自己的文章。
+	Items(ctx context.Context, listID, viewerID uint) ([]models.ReadingListItem, error)
+	CountItems(ctx context.Context, listID uint) (int64, error)
+	// AddItem 把文章追加到列表末尾，已在列表中时返回 ErrDuplicate。
+	AddItem(ctx context.Context, item *models.ReadingListItem) error
+	// RemoveItem 从列表中移除文章，不在列表中时返回 ErrNotFound。
+	RemoveItem(ctx context.Context, listID, postID uint) error
+	// Reorder 把 postIDs 依次排到列表最前面，其余文章保持原有顺序；postIDs 中有不在列表中的文章时返回 ErrNotFound。
+	Reorder(ctx context.Context, listID uint, postIDs []uint) error
+}
+
+type FollowRepository interface {
+	// Create 建立关注关系，并在同一事务中增加双方的 following_count 和 follower_count，已关注时返回 ErrDuplicate。
+	Create(ctx context.Context, follow *models.Follow) error
+	// Delete 取消关注并减少双方的计数，未关注时返回 ErrNotFound。
+	Delete(ctx context.Context, followerID, followeeID uint) error
+	// Followers 按关注时间倒序分页返回 userID 的粉丝并加载 Follower，已删除和停用的用户不返回。
+	Followers(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Follow], error)
+	// Following 按关注时间倒序分页返回 userID 关注的用户并加载 Followee，已删除和停用的用户不返回。
+	Following(ctx context.Context, userID uint, params *utils.PageParams) (*utils.PageResult[models.Follow], error)
+	// FolloweeIDs 返回 followerID 关注的所有未删除且未停用的用户 ID。
+	FolloweeIDs(ctx context.Context, followerID uint) ([]uint, error)
+}
+
+// ViewDelta 是一篇文章在某个小时内新增的浏览量。
+type ViewDelta struct {
+	PostID uint
+	Bucket time.Time
+	Views  int64
+}
+
+// TrendingQuery 描述热门文章的计分方式：Since 之后的浏览和评论按距离 Now 的时长衰减，
+// 每经过 HalfLife 减半，一条评论相当于 CommentWeight 次浏览。
+type TrendingQuery struct {
+	Since         time.Time
+	Now           time.Time
+	HalfLife      time.Duration
+	CommentWeight int64
+	Limit         int
+}
+
+// PostTrend 是一篇已发布文章在统计窗口内的热度得分、浏览量和评论数。
+type PostTrend struct {
+	PostID   uint
+	Score    float64
+	Views    int64
+	Comments int64
+}
+
+type ViewRepository interface {
+	// AddViews 在同一事务中累加 posts.view_count 和按小时的浏览统计。
+	AddViews(ctx context.Context, deltas []ViewDelta) error
+	// Trending 按文章汇总已发布文章的按小时浏览量和未删除评论，返回得分最高的 Limit 篇，
+	// 得分相同时新文章在前。
+	Trending(ctx context.Context, q TrendingQuery) ([]PostTrend, error)
+	// PruneStats 删除 before 之前的按小时浏览统计，返回删除的行数。
+	PruneStats(ctx context.Context, before time.Time) (int64, error)
+}
+
+// CounterDrift 描述一条冗余计数与源表统计结果不一致的记录。
+type CounterDrift struct {
+	Table  string
+	Column string
+	ID     uint
+	Stored int64
+	Actual int64
+}
+
+// CounterRepository 核对 posts.comment_count、users.post_count 等冗余计数。
+type CounterRepository interface {
+	// Drift 从源表重新统计，返回存储值与统计结果不一致的记录。
+	Drift(ctx context.Context) ([]CounterDrift, error)
+	// Repair 按源表重新计算给定记录的计数。
+	Repair(ctx context.Context, drifts []CounterDrift) error
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/controllers"
+	"github.com/task/go_learn_task/blog-backend/feed"
+	"github.com/task/go_learn_task/blog-backend/health"
+	"github.com/task/go_learn_task/blog-backend/mailer"
+	"github.com/task/go_learn_task/blog-backend/metrics"
+	"github.com/task/go_learn_task/blog-backend/middleware"
+	"github.com/task/go_learn_task/blog-backend/policy"
+	"github.com/task/go_learn_task/blog-backend/ratelimit"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/search"
+	"github.com/task/go_learn_task/blog-backend/service"
+	"github.com/task/go_learn_task/blog-backend/views"
+	"gorm.io/gorm"
+)
+
+// RouterOption 调整 NewRouter 组装的依赖。
+type RouterOption func(*routerOptions)
+
+type routerOptions struct {
+	tracker *views.Tracker
+}
+
+// WithViewTracker 使用调用方创建的浏览量统计，由调用方负责运行 Tracker.Run 写入数据库。
+// 未指定时 NewRouter 自行创建一个，浏览只在内存中去重和累加，不会写入数据库。
+func WithViewTracker(tracker *views.Tracker) RouterOption {
+	return func(o *routerOptions) {
+		o.tracker = tracker
+	}
+}
+
+// NewRouter 组装数据访问层、业务层和全部路由，db 需已完成迁移。
+func NewRouter(cfg *config.Config, db *gorm.DB, opts ...RouterOption) (*gin.Engine, error) {
+	var options routerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// 初始化全文搜索
+	searchEngine, err := search.NewEngine(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// 初始化指标
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	appMetrics := metrics.New(sqlDB, cfg.DBName)
+
+	// 初始化限流，单实例部署使用内存存储
+	limitStore := ratelimit.NewMemoryStore()
+	loginGuard := ratelimit.NewLoginGuard(limitStore, ratelimit.NewMemoryLockoutStore(), limitOf(cfg.LoginRateLimit), ratelimit.LockoutPolicy{
+		Threshold:   cfg.LockoutThreshold,
+		Duration:    cfg.LockoutDuration,
+		MaxDuration: cfg.LockoutMaxDuration,
+		Window:      cfg.LockoutWindow,
+	})
+
+	// 初始化数据访问层和业务层
+	userRepo := repository.NewUserRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	postRepo := repository.NewPostRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	viewRepo := repository.NewViewRepository(db)
+	reactionRepo := repository.NewReactionRepository(db)
+	bookmarkRepo := repository.NewBookmarkRepository(db)
+	readingListRepo := repository.NewReadingListRepository(db)
+	followRepo := repository.NewFollowRepository(db)
+	followeeCache := feed.NewCache(cfg.FeedCacheTTL, cfg.FeedCacheSize)
+	tracker := options.tracker
+	if tracker == nil {
+		tracker = views.NewTracker(viewRepo, cfg.ViewDedupWindow)
+	}
+
+	// 初始化邮件
+	mail, err := mailer.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	accountService := service.NewAccountService(cfg, userRepo, sessionRepo, userTokenRepo, mail, loginGuard)
+	authService := service.NewAuthService(cfg, userRepo, sessionRepo, appMetrics, loginGuard, accountService)
+	userService := service.NewUserService(userRepo, sessionRepo)
+	profileService := service.NewProfileService(userRepo, sessionRepo, postRepo)
+	postService := service.NewPostService(postRepo, categoryRepo, searchEngine, appMetrics, tracker, reactionRepo)
+	reactionService := service.NewReactionService(reactionRepo, postRepo)
+	bookmarkService := service.NewBookmarkService(bookmarkRepo, postRepo)
+	readingListService := service.NewReadingListService(readingListRepo, postRepo, userRepo)
+	followService := service.NewFollowService(followRepo, userRepo, followeeCache)
+	feedService := service.NewFeedService(followRepo, postRepo, reactionRepo, followeeCache)
+	trendingService := service.NewTrendingService(postRepo, viewRepo, cfg.TrendingHalfLife)
+	commentService := service.NewCommentService(commentRepo, postRepo, searchEngine, cfg.CommentMaxDepth, appMetrics)
+	categoryService := service.NewCategoryService(categoryRepo)
+	searchService := service.NewSearchService(postRepo, searchEngine)
+
+	// 设置Gin模式
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	// 只信任配置中的代理转发的 X-Forwarded-For，否则客户端可以伪造 IP 绕过限流
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
+
+	// 中间件
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.LoggerMiddleware())
+	router.Use(middleware.MetricsMiddleware(appMetrics))
+	router.Use(middleware.ErrorMiddleware())
+	router.Use(middleware.RecoveryMiddleware())
+
+	// 校验错误详情使用 JSON 字段名
+	apperror.UseJSONFieldNames()
+
+	// 初始化控制器
+	authController := controllers.NewAuthController(authService)
+	accountController := controllers.NewAccountController(accountService)
+	postController := controllers.NewPostController(postService)
+	commentController := controllers.NewCommentController(commentService)
+	userController := controllers.NewUserController(userService)
+	profileController := controllers.NewProfileController(profileService)
+	categoryController := controllers.NewCategoryController(categoryService)
+	tagController := controllers.NewTagController(postService)
+	searchController := controllers.NewSearchController(searchService)
+	trendingController := controllers.NewTrendingController(trendingService)
+	reactionController := controllers.NewReactionController(reactionService)
+	bookmarkController := controllers.NewBookmarkController(bookmarkService)
+	readingListController := controllers.NewReadingListController(readingListService)
+	followController := controllers.NewFollowController(followService, feedService)
+
+	// 公开路由
+	authLimit := middleware.RateLimitMiddleware(limitStore, limitOf(cfg.AuthRateLimit), "auth", middleware.ByIP)
+	router.POST("/api/register", authLimit, authController.Register)
+	router.POST("/api/login", authLimit, authController.Login)
+	router.POST("/api/token/refresh", authLimit, authController.RefreshToken)
+	router.POST("/api/email/verify", authLimit, accountController.VerifyEmail)
+	router.POST("/api/password/forgot", authLimit, accountController.ForgotPassword)
+	router.POST("/api/password/reset", authLimit, accountController.ResetPassword)
+
+	// 文章公开路由
+	router.GET("/api/posts", middleware.OptionalAuthMiddleware(authService), postController.GetAllPosts)
+	router.GET("/api/posts/trending", trendingController.GetTrending)
+	router.GET("/api/posts/:id", middleware.OptionalAuthMiddleware(authService), postController.GetPost)
+
+	// 分类、标签公开路由
+	router.GET("/api/categories", categoryController.GetCategories)
+	router.GET("/api/tags", tagController.GetTags)
+
+	// 搜索
+	router.GET("/api/search", searchController.Search)
+
+	// 用户公开主页、粉丝和关注列表，以及公开的阅读列表
+	router.GET("/api/users/:username", profileController.GetUserPage)
+	router.GET("/api/users/:username/followers", followController.GetFollowers)
+	router.GET("/api/users/:username/following", followController.GetFollowing)
+	router.GET("/api/lists/:slug", readingListController.GetPublicList)
+
+	// REQUIRE_VERIFIED_EMAIL 开启时只有验证过邮箱的用户可以发文和评论
+	verified := func(c *gin.Context) { c.Next() }
+	if cfg.RequireVerified {
+		verified = middleware.RequireVerifiedEmail()
+	}
+
+	// 认证路由组
+	auth := router.Group("/api")
+	auth.Use(middleware.AuthMiddleware(authService))
+	{
+		// 退出登录
+		auth.POST("/logout", authController.Logout)
+		auth.POST("/email/resend", authLimit, accountController.ResendVerification)
+
+		// 个人资料和账号管理
+		auth.GET("/me", profileController.GetMe)
+		auth.PUT("/me", profileController.UpdateMe)
+		auth.PUT("/me/password", authLimit, profileController.ChangePassword)
+		auth.POST("/me/deactivate", authLimit, profileController.Deactivate)
+		auth.DELETE("/me", authLimit, profileController.DeleteMe)
+
+		// 需要认证的文章操作
+		auth.POST("/posts", middleware.RequirePermission(policy.PermCreatePost), verified,
+			middleware.RateLimitMiddleware(limitStore, limitOf(cfg.PostRateLimit), "posts", middleware.ByUser),
+			postController.CreatePost)
+		auth.PUT("/posts/:id", postController.UpdatePost)
+		auth.DELETE("/posts/:id", postController.DeletePost)
+		auth.POST("/posts/:id/restore", middleware.RequirePermission(policy.PermDeleteAnyPost), postController.RestorePost)
+		auth.POST("/posts/:id/publish", postController.PublishPost)
+		auth.POST("/posts/:id/archive", postController.ArchivePost)
+		auth.GET("/me/posts", postController.GetMyPosts)
+		auth.POST("/posts/:id/reactions", reactionController.AddReaction)
+		auth.DELETE("/posts/:id/reactions", reactionController.RemoveReaction)
+
+		// 收藏和阅读列表
+		auth.POST("/posts/:id/bookmark", bookmarkController.AddBookmark)
+		auth.DELETE("/posts/:id/bookmark", bookmarkController.RemoveBookmark)
+		auth.GET("/me/bookmarks", bookmarkController.GetMyBookmarks)
+		auth.GET("/me/lists", readingListController.GetMyLists)
+		auth.POST("/me/lists", readingListController.CreateList)
+		auth.GET("/me/lists/:id", readingListController.GetList)
+		auth.PUT("/me/lists/:id", readingListController.UpdateList)
+		auth.DELETE("/me/lists/:id", readingListController.DeleteList)
+		auth.POST("/me/lists/:id/items", readingListController.AddItem)
+		auth.PUT("/me/lists/:id/items/order", readingListController.ReorderItems)
+		auth.DELETE("/me/lists/:id/items/:postId", readingListController.RemoveItem)
+
+		// 关注和关注动态
+		auth.POST("/users/:username/follow", followController.Follow)
+		auth.DELETE("/users/:username/follow", followController.Unfollow)
+		auth.GET("/feed", followController.GetFeed)
+
+		// 分类管理
+		manageCategories := middleware.RequirePermission(policy.PermManageCategories)
+		auth.POST("/categories", manageCategories, categoryController.CreateCategory)
+		auth.PUT("/categories/:id", manageCategories, categoryController.UpdateCategory)
+		auth.DELETE("/categories/:id", manageCategories, categoryController.DeleteCategory)
+
+		// 评论操作
+		auth.POST("/post-comments/:postId/comments", middleware.RequirePermission(policy.PermCreateComment), verified,
+			middleware.RateLimitMiddleware(limitStore, limitOf(cfg.CommentRateLimit), "comments", middleware.ByUser),
+			commentController.CreateComment)
+		auth.PUT("/post-comments/:postId/comments/:commentId", commentController.UpdateComment)
+		auth.DELETE("/post-comments/:postId/comments/:commentId", commentController.DeleteComment)
+		auth.POST("/post-comments/:postId/comments/:commentId/restore", middleware.RequirePermission(policy.PermDeleteAnyComment),
+			commentController.RestoreComment)
+	}
+
+	// 管理员路由组
+	admin := auth.Group("/admin")
+	admin.Use(middleware.RequirePermission(policy.PermManageUsers))
+	{
+		admin.GET("/users", userController.ListUsers)
+		admin.PUT("/users/:id/role", userController.UpdateUserRole)
+		admin.PUT("/users/:id/status", userController.UpdateUserStatus)
+		admin.DELETE("/users/:id", userController.DeleteUser)
+	}
+
+	// 评论公开路由
+	router.GET("/api/post-comments/:postId/comments", middleware.OptionalAuthMiddleware(authService), commentController.GetPostComments)
+
+	// 未知路由
+	router.NoRoute(func(c *gin.Context) {
+		c.Error(apperror.NotFound("Route not found"))
+	})
+
+	// Prometheus 指标
+	router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+
+	// 存活和就绪探针，/health 保留为 /livez 的别名
+	checker := health.NewChecker(cfg.HealthCheckTimeout)
+	checker.Add("database", sqlDB.PingContext)
+	healthController := controllers.NewHealthController(checker)
+	router.GET("/livez", healthController.Livez)
+	router.GET("/readyz", healthController.Readyz)
+	router.GET("/health", healthController.Livez)
+
+	return router, nil
+}
+
+func limitOf(r config.Rate) ratelimit.Limit {
+	return ratelimit.Limit{Count: r.Count, Per: r.Per}
+}
@@ -0,0 +1,1785 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/database"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+	"github.com/task/go_learn_task/blog-backend/views"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testServer 使用独立的内存 SQLite 数据库启动完整路由。
+type testServer struct {
+	t       *testing.T
+	cfg     *config.Config
+	db      *gorm.DB
+	router  *gin.Engine
+	tracker *views.Tracker
+}
+
+type apiResponse struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Error   string                `json:"error"`
+	Data    json.RawMessage       `json:"data"`
+	Code    string                `json:"code"`
+	Details []apperror.FieldError `json:"details"`
+}
+
+type authData struct {
+	User         models.User `json:"user"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refresh_token"`
+}
+
+type pageData struct {
+	Items      []models.Post `json:"items"`
+	Page       int           `json:"page"`
+	Limit      int           `json:"limit"`
+	Total      int64         `json:"total"`
+	HasNext    bool          `json:"has_next"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+// newTestServer 启动测试服务器，opts 可在连接数据库前调整配置。
+func newTestServer(t *testing.T, opts ...func(*config.Config)) *testServer {
+	t.Helper()
+
+	cfg := &config.Config{
+		DBDriver:           database.DriverSQLite,
+		DBPath:             ":memory:",
+		JWTSecret:          "test-secret",
+		AccessTokenTTL:     15 * time.Minute,
+		RefreshTokenTTL:    time.Hour,
+		CommentMaxDepth:    5,
+		PublishInterval:    time.Minute,
+		HealthCheckTimeout: time.Second,
+		PublicURL:          "http://blog.test",
+		MailDriver:         "file",
+		MailFrom:           "Blog <no-reply@blog.test>",
+		MailOutboxDir:      t.TempDir(),
+		EmailVerifyTTL:     time.Hour,
+		PasswordResetTTL:   time.Hour,
+		ViewDedupWindow:    30 * time.Minute,
+		TrendingHalfLife:   6 * time.Hour,
+		FeedCacheTTL:       5 * time.Minute,
+		FeedCacheSize:      100,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		t.Fatalf("connect db: %v", err)
+	}
+	db = db.Session(&gorm.Session{Logger: logger.Discard})
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := database.MigrateDB(db); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	tracker := views.NewTracker(repository.NewViewRepository(db), cfg.ViewDedupWindow)
+	router, err := NewRouter(cfg, db, WithViewTracker(tracker))
+	if err != nil {
+		t.Fatalf("new router: %v", err)
+	}
+	return &testServer{t: t, cfg: cfg, db: db, router: router, tracker: tracker}
+}
+
+func (s *testServer) do(method, path, token string, body interface{}) (int, apiResponse) {
+	s.t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var resp apiResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		s.t.Fatalf("%s %s: decode response %q: %v", method, path, w.Body.String(), err)
+	}
+	return w.Code, resp
+}
+
+// expect 发送请求并断言状态码，返回解析后的响应。
+func (s *testServer) expect(status int, method, path, token string, body interface{}) apiResponse {
+	s.t.Helper()
+
+	code, resp := s.do(method, path, token, body)
+	if code != status {
+		s.t.Fatalf("%s %s: status = %d, want %d (message %q)", method, path, code, status, resp.Message)
+	}
+	return resp
+}
+
+func decode[T any](t *testing.T, resp apiResponse) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal(resp.Data, &v); err != nil {
+		t.Fatalf("decode data %s: %v", resp.Data, err)
+	}
+	return v
+}
+
+func (s *testServer) register(name string) authData {
+	s.t.Helper()
+
+	resp := s.expect(http.StatusCreated, http.MethodPost, "/api/register", "", gin.H{
+		"username": name,
+		"email":    name + "@example.com",
+		"password": "secret123",
+	})
+	return decode[authData](s.t, resp)
+}
+
+func (s *testServer) createPost(token, title string, publish bool) models.Post {
+	s.t.Helper()
+
+	resp := s.expect(http.StatusCreated, http.MethodPost, "/api/posts", token, gin.H{
+		"title":   title,
+		"content": "content of " + title,
+	})
+	post := decode[models.Post](s.t, resp)
+	if publish {
+		s.expect(http.StatusOK, http.MethodPost, fmt.Sprintf("/api/posts/%d/publish", post.ID), token, nil)
+	}
+	return post
+}
+
+func TestNewRouterDefaultTracker(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	post := s.createPost(alice.Token, "Viewed", true)
+
+	// 不传 WithViewTracker 时使用内部创建的 Tracker
+	router, err := NewRouter(s.cfg, s.db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/posts/%d", post.ID), nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("GET post status = %d, body %s", w.Code, w.Body)
+	}
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	s := newTestServer(t)
+
+	alice := s.register("alice")
+	if alice.Token == "" || alice.RefreshToken == "" {
+		t.Fatalf("register returned empty tokens: %+v", alice)
+	}
+	if alice.User.Role != models.RoleAuthor {
+		t.Errorf("role = %q, want %q", alice.User.Role, models.RoleAuthor)
+	}
+
+	s.expect(http.StatusConflict, http.MethodPost, "/api/register", "", gin.H{
+		"username": "alice",
+		"email":    "alice@example.com",
+		"password": "secret123",
+	})
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/register", "", gin.H{
+		"username": "bob",
+		"email":    "not-an-email",
+		"password": "secret123",
+	})
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/login", "", gin.H{
+		"email":    "alice@example.com",
+		"password": "wrong-password",
+	})
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/login", "", gin.H{
+		"email":    "nobody@example.com",
+		"password": "secret123",
+	})
+
+	login := decode[authData](t, s.expect(http.StatusOK, http.MethodPost, "/api/login", "", gin.H{
+		"email":    "alice@example.com",
+		"password": "secret123",
+	}))
+	if login.User.ID != alice.User.ID {
+		t.Errorf("login user id = %d, want %d", login.User.ID, alice.User.ID)
+	}
+	s.expect(http.StatusOK, http.MethodGet, "/api/me/posts", login.Token, nil)
+}
+
+func TestJWTFailureModes(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	claims, err := utils.ValidateToken(alice.Token, s.cfg)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+
+	expiredCfg := *s.cfg
+	expiredCfg.AccessTokenTTL = -time.Minute
+	expired, err := utils.GenerateToken(&alice.User, claims.ID, &expiredCfg)
+	if err != nil {
+		t.Fatalf("generate expired token: %v", err)
+	}
+
+	otherCfg := *s.cfg
+	otherCfg.JWTSecret = "other-secret"
+	forged, err := utils.GenerateToken(&alice.User, claims.ID, &otherCfg)
+	if err != nil {
+		t.Fatalf("generate forged token: %v", err)
+	}
+
+	unknownSession, err := utils.GenerateToken(&alice.User, "unknown-jti", s.cfg)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing", ""},
+		{"malformed", "not-a-jwt"},
+		{"expired", expired},
+		{"wrong signature", forged},
+		{"unknown session", unknownSession},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me/posts", tt.token, nil)
+			if resp.Success || resp.Code != apperror.CodeUnauthorized {
+				t.Errorf("success = %v, code = %q for rejected token", resp.Success, resp.Code)
+			}
+		})
+	}
+
+	t.Run("logged out", func(t *testing.T) {
+		s.expect(http.StatusOK, http.MethodPost, "/api/logout", alice.Token, nil)
+		s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me/posts", alice.Token, nil)
+	})
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	refresh := func(status int, token string) authData {
+		t.Helper()
+		return decode[authData](t, s.expect(status, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": token}))
+	}
+
+	// 刷新后签发新的令牌对，旧的访问令牌仍在有效期内
+	rotated := refresh(http.StatusOK, alice.RefreshToken)
+	if rotated.Token == "" || rotated.RefreshToken == "" || rotated.RefreshToken == alice.RefreshToken {
+		t.Fatalf("refresh returned %+v", rotated)
+	}
+	s.expect(http.StatusOK, http.MethodGet, "/api/me", rotated.Token, nil)
+	next := refresh(http.StatusOK, rotated.RefreshToken)
+
+	// 重放已轮换的刷新令牌视为泄露，整个会话 family 被吊销
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": alice.RefreshToken})
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": next.RefreshToken})
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", next.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", alice.Token, nil)
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": "not-a-token"})
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/token/refresh", "", gin.H{})
+}
+
+func TestLogoutRevokesSession(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	other := decode[authData](t, s.expect(http.StatusOK, http.MethodPost, "/api/login", "", gin.H{
+		"email":    "alice@example.com",
+		"password": "secret123",
+	}))
+	rotated := decode[authData](t, s.expect(http.StatusOK, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": alice.RefreshToken}))
+
+	// 退出登录吊销访问令牌所属的会话，包括轮换前后的令牌，其他会话不受影响
+	s.expect(http.StatusOK, http.MethodPost, "/api/logout", rotated.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", rotated.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", alice.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": rotated.RefreshToken})
+	s.expect(http.StatusOK, http.MethodGet, "/api/me", other.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/logout", rotated.Token, nil)
+}
+
+func TestPostOwnership(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/posts", "", gin.H{"title": "t", "content": "c"})
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/posts", alice.Token, gin.H{"title": "no content"})
+
+	post := s.createPost(alice.Token, "Alice's post", false)
+	path := fmt.Sprintf("/api/posts/%d", post.ID)
+
+	// 草稿只有作者本人可见
+	s.expect(http.StatusOK, http.MethodGet, path, alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodGet, path, bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodGet, path, "", nil)
+
+	s.expect(http.StatusForbidden, http.MethodPut, path, bob.Token, gin.H{"title": "hijacked"})
+	s.expect(http.StatusForbidden, http.MethodDelete, path, bob.Token, nil)
+	s.expect(http.StatusForbidden, http.MethodPost, path+"/publish", bob.Token, nil)
+	s.expect(http.StatusBadRequest, http.MethodPost, path+"/archive", alice.Token, nil)
+
+	updated := decode[models.Post](t, s.expect(http.StatusOK, http.MethodPut, path, alice.Token, gin.H{"title": "Updated"}))
+	if updated.Title != "Updated" {
+		t.Errorf("title = %q, want %q", updated.Title, "Updated")
+	}
+
+	s.expect(http.StatusOK, http.MethodPost, path+"/publish", alice.Token, nil)
+	got := decode[models.Post](t, s.expect(http.StatusOK, http.MethodGet, path, "", nil))
+	if got.Status != models.PostStatusPublished || got.Title != "Updated" {
+		t.Errorf("post = %q/%q, want published/Updated", got.Status, got.Title)
+	}
+
+	s.expect(http.StatusOK, http.MethodDelete, path, alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodGet, path, alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPut, path, alice.Token, gin.H{"title": "gone"})
+	s.expect(http.StatusBadRequest, http.MethodGet, "/api/posts/abc", "", nil)
+}
+
+// TestPostUpdateKeepsConcurrentChanges 模拟编辑期间文章被定时发布或删除，编辑不应覆盖这些修改。
+func TestPostUpdateKeepsConcurrentChanges(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	alice := s.register("alice")
+	posts := repository.NewPostRepository(s.db)
+
+	draft := s.createPost(alice.Token, "Scheduled", false)
+	stale, err := posts.FindByID(ctx, draft.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := posts.UpdateStatus(ctx, draft.ID, models.PostStatusDraft, models.PostStatusPublished, &now); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	stale.Title = "Edited"
+	if err := posts.Update(ctx, stale, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := posts.FindByID(ctx, draft.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Edited" || got.Status != models.PostStatusPublished || got.PublishedAt == nil {
+		t.Errorf("after edit: title=%q status=%q published_at=%v", got.Title, got.Status, got.PublishedAt)
+	}
+	// 状态已被修改时，基于旧状态的修改不生效
+	if err := posts.UpdateStatus(ctx, draft.ID, models.PostStatusDraft, models.PostStatusArchived, nil); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("stale UpdateStatus() error = %v, want ErrNotFound", err)
+	}
+	path := fmt.Sprintf("/api/posts/%d", draft.ID)
+	s.expect(http.StatusOK, http.MethodPost, path+"/publish", alice.Token, nil)
+
+	// 编辑期间被删除的文章不会因为保存而恢复
+	s.expect(http.StatusOK, http.MethodDelete, path, alice.Token, nil)
+	if err := posts.Update(ctx, stale, nil); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Update() on deleted post error = %v, want ErrNotFound", err)
+	}
+	s.expect(http.StatusNotFound, http.MethodGet, path, "", nil)
+	var rows int64
+	s.db.Unscoped().Model(&models.Post{}).Where("id = ?", draft.ID).Count(&rows)
+	if rows != 1 {
+		t.Errorf("rows for post = %d, want 1", rows)
+	}
+}
+
+func TestTagsAndCategories(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.AdminEmail = "admin@example.com" })
+	admin := s.register("admin")
+	alice := s.register("alice")
+
+	// 分类只能由管理员维护
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/categories", "", gin.H{"name": "Go"})
+	s.expect(http.StatusForbidden, http.MethodPost, "/api/categories", alice.Token, gin.H{"name": "Go"})
+	golang := decode[models.Category](t, s.expect(http.StatusCreated, http.MethodPost, "/api/categories", admin.Token, gin.H{"name": "Go Lang"}))
+	if golang.Slug != "go-lang" {
+		t.Errorf("slug = %q, want go-lang", golang.Slug)
+	}
+	rust := decode[models.Category](t, s.expect(http.StatusCreated, http.MethodPost, "/api/categories", admin.Token, gin.H{"name": "Rust"}))
+	s.expect(http.StatusConflict, http.MethodPost, "/api/categories", admin.Token, gin.H{"name": "Other", "slug": "rust"})
+	golangPath := fmt.Sprintf("/api/categories/%d", golang.ID)
+	s.expect(http.StatusForbidden, http.MethodPut, golangPath, alice.Token, gin.H{"name": "Hijacked"})
+	s.expect(http.StatusForbidden, http.MethodDelete, golangPath, alice.Token, nil)
+	s.expect(http.StatusOK, http.MethodPut, golangPath, admin.Token, gin.H{"slug": "go"})
+
+	create := func(title string, category *uint, tags []string, publish bool) {
+		t.Helper()
+		post := decode[models.Post](t, s.expect(http.StatusCreated, http.MethodPost, "/api/posts", alice.Token, gin.H{
+			"title":       title,
+			"content":     "content of " + title,
+			"category_id": category,
+			"tags":        tags,
+		}))
+		if publish {
+			s.expect(http.StatusOK, http.MethodPost, fmt.Sprintf("/api/posts/%d/publish", post.ID), alice.Token, nil)
+		}
+	}
+	create("Goroutines", &golang.ID, []string{"Concurrency", "go"}, true)
+	create("Channels", &golang.ID, []string{"go"}, true)
+	create("Ownership", &rust.ID, []string{"concurrency"}, true)
+	create("Untagged", nil, nil, true)
+	create("Draft", &golang.ID, []string{"go", "draft-only"}, false)
+
+	list := func(query string) []string {
+		t.Helper()
+		return titles(decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts?sort=title&order=asc&"+query, "", nil)).Items)
+	}
+	for query, want := range map[string][]string{
+		"tag=go":               {"Channels", "Goroutines"},
+		"tag=CONCURRENCY":      {"Goroutines", "Ownership"},
+		"tag=draft-only":       nil,
+		"category=go":          {"Channels", "Goroutines"},
+		"category=rust":        {"Ownership"},
+		"category=go&tag=go":   {"Channels", "Goroutines"},
+		"category=rust&tag=go": nil,
+		"category=go-lang":     nil,
+	} {
+		if got := list(query); !slices.Equal(got, want) {
+			t.Errorf("GET /api/posts?%s = %v, want %v", query, got, want)
+		}
+	}
+
+	// 标签列表只统计已发布的文章
+	tags := decode[[]models.TagWithCount](t, s.expect(http.StatusOK, http.MethodGet, "/api/tags", "", nil))
+	var counts []string
+	for _, tag := range tags {
+		counts = append(counts, fmt.Sprintf("%s=%d", tag.Name, tag.PostCount))
+	}
+	if want := []string{"concurrency=2", "go=2", "draft-only=0"}; !slices.Equal(counts, want) {
+		t.Errorf("tags = %v, want %v", counts, want)
+	}
+
+	// 删除分类后文章变为未分类
+	s.expect(http.StatusOK, http.MethodDelete, golangPath, admin.Token, nil)
+	if got := list("category=go"); len(got) != 0 {
+		t.Errorf("posts in deleted category = %v", got)
+	}
+	categories := decode[[]models.Category](t, s.expect(http.StatusOK, http.MethodGet, "/api/categories", "", nil))
+	if len(categories) != 1 || categories[0].Slug != "rust" {
+		t.Errorf("categories = %+v, want only rust", categories)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+	type searchPage struct {
+		Items []struct {
+			Post    models.Post `json:"post"`
+			Title   string      `json:"title_highlight"`
+			Snippet string      `json:"snippet"`
+		} `json:"items"`
+		Total int64 `json:"total"`
+	}
+	search := func(query string) searchPage {
+		t.Helper()
+		return decode[searchPage](t, s.expect(http.StatusOK, http.MethodGet, "/api/search?q="+url.QueryEscape(query), "", nil))
+	}
+	found := func(query string) []string {
+		t.Helper()
+		page := search(query)
+		var got []string
+		for _, item := range page.Items {
+			got = append(got, item.Post.Title)
+		}
+		if int(page.Total) != len(got) {
+			t.Errorf("search %q total = %d, items = %v", query, page.Total, got)
+		}
+		return got
+	}
+
+	s.expect(http.StatusBadRequest, http.MethodGet, "/api/search?q=+", "", nil)
+
+	// 草稿在发布前不会出现在搜索结果中
+	post := s.createPost(alice.Token, "Gopher secrets", false)
+	s.createPost(bob.Token, "Gopher draft", false)
+	path := fmt.Sprintf("/api/posts/%d", post.ID)
+	if got := found("gopher"); len(got) != 0 {
+		t.Errorf("drafts found: %v", got)
+	}
+	s.expect(http.StatusOK, http.MethodPost, path+"/publish", alice.Token, nil)
+	page := search("GOPHER")
+	if len(page.Items) != 1 || page.Items[0].Post.ID != post.ID {
+		t.Fatalf("search after publish = %+v", page)
+	}
+	if title := page.Items[0].Title; title != "<mark>Gopher</mark> secrets" {
+		t.Errorf("title highlight = %q", title)
+	}
+
+	// 修改后按新内容检索，评论只在 comments=true 时参与匹配
+	s.expect(http.StatusOK, http.MethodPut, path, alice.Token, gin.H{"title": "Rustacean secrets", "content": "crabs everywhere"})
+	if got := found("gopher"); len(got) != 0 {
+		t.Errorf("stale title found: %v", got)
+	}
+	if got := found("crabs"); !slices.Equal(got, []string{"Rustacean secrets"}) {
+		t.Errorf("search crabs = %v", got)
+	}
+	s.expect(http.StatusCreated, http.MethodPost, fmt.Sprintf("/api/post-comments/%d/comments", post.ID), bob.Token, gin.H{"content": "gopher fan here"})
+	if got := found("gopher"); len(got) != 0 {
+		t.Errorf("comment matched without comments=true: %v", got)
+	}
+	withComments := decode[searchPage](t, s.expect(http.StatusOK, http.MethodGet, "/api/search?q=gopher&comments=true", "", nil))
+	if len(withComments.Items) != 1 || withComments.Items[0].Post.ID != post.ID {
+		t.Errorf("search with comments = %+v", withComments)
+	}
+
+	// 归档和删除的文章不再出现
+	s.expect(http.StatusOK, http.MethodPost, path+"/archive", alice.Token, nil)
+	if got := found("rustacean"); len(got) != 0 {
+		t.Errorf("archived post found: %v", got)
+	}
+	s.expect(http.StatusOK, http.MethodPost, path+"/publish", alice.Token, nil)
+	s.expect(http.StatusOK, http.MethodDelete, path, alice.Token, nil)
+	if got := found("rustacean"); len(got) != 0 {
+		t.Errorf("deleted post found: %v", got)
+	}
+}
+
+func TestCommentOnMissingPost(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	s.expect(http.StatusNotFound, http.MethodPost, "/api/post-comments/999/comments", alice.Token, gin.H{"content": "hello"})
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/post-comments/999/comments", "", nil)
+
+	draft := s.createPost(alice.Token, "Draft", false)
+	s.expect(http.StatusBadRequest, http.MethodPost, fmt.Sprintf("/api/post-comments/%d/comments", draft.ID), alice.Token, gin.H{"content": "hello"})
+
+	post := s.createPost(alice.Token, "Published", true)
+	path := fmt.Sprintf("/api/post-comments/%d/comments", post.ID)
+	s.expect(http.StatusUnauthorized, http.MethodPost, path, "", gin.H{"content": "hello"})
+	s.expect(http.StatusNotFound, http.MethodPost, path, alice.Token, gin.H{"content": "reply", "parent_id": 999})
+
+	comment := decode[models.Comment](t, s.expect(http.StatusCreated, http.MethodPost, path, alice.Token, gin.H{"content": "hello"}))
+	if comment.PostID != post.ID || comment.Content != "hello" {
+		t.Errorf("comment = %+v", comment)
+	}
+
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("/api/posts/%d", post.ID), alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPost, path, alice.Token, gin.H{"content": "too late"})
+}
+
+func TestCommentUpdateAndDelete(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.AdminEmail = "admin@example.com" })
+	admin := s.register("admin")
+	alice := s.register("alice")
+	bob := s.register("bob")
+	carol := s.register("carol")
+	mod := s.register("mod")
+	s.expect(http.StatusOK, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/role", mod.User.ID), admin.Token, gin.H{"role": models.RoleModerator})
+
+	post := s.createPost(alice.Token, "Discussed", true)
+	other := s.createPost(alice.Token, "Other", true)
+	path := fmt.Sprintf("/api/post-comments/%d/comments", post.ID)
+	create := func(token, content string) string {
+		t.Helper()
+		comment := decode[models.Comment](t, s.expect(http.StatusCreated, http.MethodPost, path, token, gin.H{"content": content}))
+		return fmt.Sprintf("%s/%d", path, comment.ID)
+	}
+	bobPath := create(bob.Token, "first")
+	carolPath := create(carol.Token, "second")
+	contents := func() []string {
+		t.Helper()
+		page := decode[struct {
+			Items []models.Comment `json:"items"`
+		}](t, s.expect(http.StatusOK, http.MethodGet, path, "", nil))
+		var got []string
+		for _, comment := range page.Items {
+			got = append(got, comment.Content)
+		}
+		return got
+	}
+
+	// 只有评论作者可以修改，文章作者和版主也不行
+	s.expect(http.StatusUnauthorized, http.MethodPut, bobPath, "", gin.H{"content": "edited"})
+	s.expect(http.StatusForbidden, http.MethodPut, bobPath, alice.Token, gin.H{"content": "edited"})
+	s.expect(http.StatusForbidden, http.MethodPut, bobPath, mod.Token, gin.H{"content": "edited"})
+	s.expect(http.StatusBadRequest, http.MethodPut, bobPath, bob.Token, gin.H{"content": ""})
+	s.expect(http.StatusNotFound, http.MethodPut, fmt.Sprintf("/api/post-comments/%d/comments/999", post.ID), bob.Token, gin.H{"content": "edited"})
+	wrongPost := strings.Replace(bobPath, fmt.Sprintf("/%d/", post.ID), fmt.Sprintf("/%d/", other.ID), 1)
+	s.expect(http.StatusNotFound, http.MethodPut, wrongPost, bob.Token, gin.H{"content": "edited"})
+	edited := decode[models.Comment](t, s.expect(http.StatusOK, http.MethodPut, bobPath, bob.Token, gin.H{"content": "edited"}))
+	if edited.Content != "edited" {
+		t.Errorf("updated content = %q", edited.Content)
+	}
+	if got := contents(); !slices.Equal(got, []string{"edited", "second"}) {
+		t.Errorf("comments after update = %v", got)
+	}
+
+	// 评论作者、文章作者和版主可以删除，其他用户不行
+	s.expect(http.StatusForbidden, http.MethodDelete, carolPath, bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodDelete, wrongPost, bob.Token, nil)
+	s.expect(http.StatusOK, http.MethodDelete, carolPath, alice.Token, nil)
+	s.expect(http.StatusOK, http.MethodDelete, bobPath, mod.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPut, bobPath, bob.Token, gin.H{"content": "again"})
+	if got := contents(); len(got) != 0 {
+		t.Errorf("comments after delete = %v, want none", got)
+	}
+	own := create(carol.Token, "third")
+	s.expect(http.StatusOK, http.MethodDelete, own, carol.Token, nil)
+}
+
+func TestCommentTree(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+	post := s.createPost(alice.Token, "Threaded", true)
+	path := fmt.Sprintf("/api/post-comments/%d/comments", post.ID)
+	reply := func(token, content string, parent *models.Comment) *models.Comment {
+		t.Helper()
+		body := gin.H{"content": content}
+		if parent != nil {
+			body["parent_id"] = parent.ID
+		}
+		comment := decode[models.Comment](t, s.expect(http.StatusCreated, http.MethodPost, path, token, body))
+		return &comment
+	}
+	root := reply(alice.Token, "root", nil)
+	child := reply(bob.Token, "child", root)
+	reply(alice.Token, "grandchild", child)
+	other := reply(bob.Token, "other", nil)
+	leaf := reply(alice.Token, "leaf", other)
+
+	// render 把评论树展开成 "内容(回复...)" 形式，便于整体比较
+	var render func(nodes []*models.CommentNode) string
+	render = func(nodes []*models.CommentNode) string {
+		parts := make([]string, len(nodes))
+		for i, node := range nodes {
+			parts[i] = node.Content
+			if len(node.Replies) > 0 {
+				parts[i] += "(" + render(node.Replies) + ")"
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	tree := func() []*models.CommentNode {
+		t.Helper()
+		return decode[[]*models.CommentNode](t, s.expect(http.StatusOK, http.MethodGet, path+"?format=tree", "", nil))
+	}
+	if got := render(tree()); got != "root(child(grandchild)) other(leaf)" {
+		t.Errorf("tree = %q", got)
+	}
+
+	// 有回复的评论删除后以占位保留，没有回复的直接移除
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("%s/%d", path, root.ID), alice.Token, nil)
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("%s/%d", path, leaf.ID), alice.Token, nil)
+	nodes := tree()
+	if got := render(nodes); got != models.DeletedCommentPlaceholder+"(child(grandchild)) other" {
+		t.Errorf("tree after delete = %q", got)
+	}
+	if placeholder := nodes[0]; !placeholder.Deleted || placeholder.UserID != 0 || placeholder.User.Username != "" {
+		t.Errorf("deleted node leaks author: %+v", placeholder.Comment)
+	}
+
+	s.expect(http.StatusBadRequest, http.MethodGet, path+"?format=nested", "", nil)
+	draft := s.createPost(alice.Token, "Draft", false)
+	s.expect(http.StatusNotFound, http.MethodGet, fmt.Sprintf("/api/post-comments/%d/comments?format=tree", draft.ID), "", nil)
+	s.expect(http.StatusOK, http.MethodGet, fmt.Sprintf("/api/post-comments/%d/comments?format=tree", draft.ID), alice.Token, nil)
+}
+
+func TestPostPagination(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	const total = 25
+	for i := 0; i < total; i++ {
+		s.createPost(alice.Token, fmt.Sprintf("Post %02d", i), true)
+	}
+	s.createPost(alice.Token, "Hidden draft", false)
+
+	first := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts", "", nil))
+	if len(first.Items) != utils.DefaultPageLimit || first.Total != total || !first.HasNext {
+		t.Fatalf("default page: items=%d total=%d has_next=%v", len(first.Items), first.Total, first.HasNext)
+	}
+
+	last := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts?page=3&limit=10", "", nil))
+	if len(last.Items) != 5 || last.HasNext {
+		t.Errorf("page 3: items=%d has_next=%v, want 5/false", len(last.Items), last.HasNext)
+	}
+
+	clamped := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts?limit=1000", "", nil))
+	if clamped.Limit != utils.MaxPageLimit || len(clamped.Items) != total {
+		t.Errorf("clamped: limit=%d items=%d", clamped.Limit, len(clamped.Items))
+	}
+
+	byTitle := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts?sort=title&limit=3", "", nil))
+	if len(byTitle.Items) != 3 || byTitle.Items[0].Title != "Post 00" || byTitle.Items[2].Title != "Post 02" {
+		t.Errorf("sort=title: unexpected order %v", titles(byTitle.Items))
+	}
+
+	for _, query := range []string{"page=0", "page=abc", "limit=0", "sort=password", "cursor=bogus"} {
+		s.expect(http.StatusBadRequest, http.MethodGet, "/api/posts?"+query, "", nil)
+	}
+
+	// 游标分页遍历全部文章，不重复也不遗漏
+	seen := make(map[uint]bool)
+	path := "/api/posts?limit=7"
+	for pages := 0; ; pages++ {
+		if pages > total {
+			t.Fatal("cursor pagination did not terminate")
+		}
+		page := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, path, "", nil))
+		for _, post := range page.Items {
+			if seen[post.ID] {
+				t.Fatalf("post %d returned twice", post.ID)
+			}
+			seen[post.ID] = true
+		}
+		if !page.HasNext {
+			break
+		}
+		if page.NextCursor == "" {
+			t.Fatal("has_next without next_cursor")
+		}
+		path = "/api/posts?limit=7&cursor=" + page.NextCursor
+	}
+	if len(seen) != total {
+		t.Errorf("cursor pagination returned %d posts, want %d", len(seen), total)
+	}
+}
+
+func TestErrorResponses(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	resp := s.expect(http.StatusBadRequest, http.MethodPost, "/api/register", "", gin.H{
+		"username": "ab",
+		"email":    "not-an-email",
+	})
+	if resp.Code != apperror.CodeValidationFailed {
+		t.Errorf("code = %q, want %q", resp.Code, apperror.CodeValidationFailed)
+	}
+	if resp.Error == "" || resp.Error != resp.Message {
+		t.Errorf("error = %q, want the message %q", resp.Error, resp.Message)
+	}
+	rules := make(map[string]string)
+	for _, d := range resp.Details {
+		rules[d.Field] = d.Rule
+	}
+	want := map[string]string{"username": "min", "email": "email", "password": "required"}
+	for field, rule := range want {
+		if rules[field] != rule {
+			t.Errorf("details[%s] rule = %q, want %q (details %+v)", field, rules[field], rule, resp.Details)
+		}
+	}
+
+	resp = s.expect(http.StatusBadRequest, http.MethodPost, "/api/posts", alice.Token, gin.H{
+		"title":   "t",
+		"content": "c",
+		"tags":    []string{strings.Repeat("x", models.MaxTagLength+1)},
+	})
+	if len(resp.Details) != 1 || resp.Details[0].Field != "tags[0]" || resp.Details[0].Rule != "max" {
+		t.Errorf("tag details = %+v, want tags[0] max", resp.Details)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader("{not json"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), apperror.CodeInvalidInput) {
+		t.Errorf("malformed json: %d %s", w.Code, w.Body.String())
+	}
+
+	resp = s.expect(http.StatusNotFound, http.MethodGet, "/api/posts/999", "", nil)
+	if resp.Code != apperror.CodeNotFound {
+		t.Errorf("missing post code = %q", resp.Code)
+	}
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/nope", "", nil)
+
+	resp = s.expect(http.StatusForbidden, http.MethodGet, "/api/admin/users", alice.Token, nil)
+	if resp.Code != apperror.CodeForbidden {
+		t.Errorf("admin code = %q", resp.Code)
+	}
+
+	// 内部错误和 panic 只返回通用提示
+	s.router.GET("/test/internal", func(c *gin.Context) {
+		c.Error(errors.New("dial tcp 10.0.0.1:3306: secret detail"))
+	})
+	s.router.GET("/test/panic", func(c *gin.Context) {
+		panic("secret detail")
+	})
+	for _, path := range []string{"/test/internal", "/test/panic"} {
+		resp = s.expect(http.StatusInternalServerError, http.MethodGet, path, "", nil)
+		if resp.Code != apperror.CodeInternal || strings.Contains(resp.Message, "secret") {
+			t.Errorf("%s: code = %q, message = %q", path, resp.Code, resp.Message)
+		}
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, tt := range []struct {
+		header string
+		echoed bool
+	}{
+		{"client-id-123", true},
+		{"bad id\nwith newline", false},
+		{"", false},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		if tt.header != "" {
+			req.Header.Set("X-Request-ID", tt.header)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		got := w.Header().Get("X-Request-ID")
+		if got == "" {
+			t.Errorf("header %q: no X-Request-ID in response", tt.header)
+		}
+		if (got == tt.header) != tt.echoed {
+			t.Errorf("header %q: response id = %q, echoed want %v", tt.header, got, tt.echoed)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/login", "", gin.H{
+		"email":    "alice@example.com",
+		"password": "wrong-password",
+	})
+	post := s.createPost(alice.Token, "Metrics", true)
+	s.expect(http.StatusCreated, http.MethodPost, fmt.Sprintf("/api/post-comments/%d/comments", post.ID), alice.Token, gin.H{"content": "hi"})
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/posts/999", "", nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /metrics: status = %d", w.Code)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		"blog_user_registrations_total 1",
+		`blog_logins_total{result="failed"} 1`,
+		`blog_logins_total{result="succeeded"} 0`,
+		"blog_posts_created_total 1",
+		"blog_comments_created_total 1",
+		`blog_http_requests_total{method="POST",route="/api/posts",status="201"} 1`,
+		`blog_http_requests_total{method="GET",route="/api/posts/:id",status="404"} 1`,
+		`blog_http_request_duration_seconds_count{method="POST",route="/api/register"} 1`,
+		"go_sql_max_open_connections",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+}
+
+func TestHealthProbes(t *testing.T) {
+	s := newTestServer(t)
+
+	s.expect(http.StatusOK, http.MethodGet, "/livez", "", nil)
+	resp := s.expect(http.StatusOK, http.MethodGet, "/readyz", "", nil)
+	if !strings.Contains(string(resp.Data), `"database":{"status":"ok"`) {
+		t.Errorf("readyz data = %s", resp.Data)
+	}
+
+	// 数据库不可用时就绪检查失败，存活检查不受影响
+	if err := database.CloseDB(s.db); err != nil {
+		t.Fatalf("close db: %v", err)
+	}
+	resp = s.expect(http.StatusServiceUnavailable, http.MethodGet, "/readyz", "", nil)
+	if resp.Code != apperror.CodeUnavailable {
+		t.Errorf("code = %q, want %q", resp.Code, apperror.CodeUnavailable)
+	}
+	s.expect(http.StatusOK, http.MethodGet, "/livez", "", nil)
+	s.expect(http.StatusOK, http.MethodGet, "/health", "", nil)
+}
+
+func TestLoginLockout(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.LockoutThreshold = 3
+		cfg.LockoutDuration = time.Minute
+		cfg.LockoutMaxDuration = time.Hour
+		cfg.LockoutWindow = 15 * time.Minute
+	})
+	s.register("alice")
+
+	wrong := gin.H{"email": "alice@example.com", "password": "wrong-password"}
+	for i := 0; i < 3; i++ {
+		s.expect(http.StatusUnauthorized, http.MethodPost, "/api/login", "", wrong)
+	}
+
+	// 锁定期间即使密码正确也被拒绝
+	req := httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(`{"email":"alice@example.com","password":"secret123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("login while locked: status = %d, body = %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+	var resp apiResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Code != apperror.CodeRateLimited {
+		t.Errorf("code = %q (err %v), want %q", resp.Code, err, apperror.CodeRateLimited)
+	}
+
+	// 其他账号不受影响
+	s.register("bob")
+	s.expect(http.StatusOK, http.MethodPost, "/api/login", "", gin.H{"email": "bob@example.com", "password": "secret123"})
+}
+
+func TestWriteQuotas(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.PostRateLimit = config.Rate{Count: 2, Per: time.Hour}
+		cfg.AuthRateLimit = config.Rate{Count: 3, Per: time.Minute}
+	})
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	s.createPost(alice.Token, "First", true)
+	s.createPost(alice.Token, "Second", true)
+	resp := s.expect(http.StatusTooManyRequests, http.MethodPost, "/api/posts", alice.Token, gin.H{"title": "Third", "content": "content"})
+	if resp.Code != apperror.CodeRateLimited {
+		t.Errorf("code = %q, want %q", resp.Code, apperror.CodeRateLimited)
+	}
+	// 配额按用户计算
+	s.createPost(bob.Token, "Bob's post", true)
+
+	// 两次注册已用掉 2 个令牌，同一 IP 的第 4 次认证请求被拒绝
+	login := gin.H{"email": "alice@example.com", "password": "secret123"}
+	s.expect(http.StatusOK, http.MethodPost, "/api/login", "", login)
+	s.expect(http.StatusTooManyRequests, http.MethodPost, "/api/login", "", login)
+}
+
+// mailToken 返回发往 to 的最近一封邮件中链接携带的令牌。
+func (s *testServer) mailToken(to, path string) string {
+	s.t.Helper()
+
+	entries, err := os.ReadDir(s.cfg.MailOutboxDir)
+	if err != nil {
+		s.t.Fatalf("read outbox: %v", err)
+	}
+	pattern := regexp.MustCompile(regexp.QuoteMeta(s.cfg.PublicURL+path) + `\?token=(\S+)`)
+	for i := len(entries) - 1; i >= 0; i-- {
+		data, err := os.ReadFile(filepath.Join(s.cfg.MailOutboxDir, entries[i].Name()))
+		if err != nil {
+			s.t.Fatalf("read mail: %v", err)
+		}
+		if !strings.Contains(string(data), "To: <"+to+">") {
+			continue
+		}
+		if match := pattern.FindStringSubmatch(string(data)); match != nil {
+			token, err := url.QueryUnescape(match[1])
+			if err != nil {
+				s.t.Fatalf("unescape token: %v", err)
+			}
+			return token
+		}
+	}
+	s.t.Fatalf("no mail to %s with a %s link", to, path)
+	return ""
+}
+
+func TestEmailVerification(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.RequireVerified = true })
+	alice := s.register("alice")
+	if alice.User.EmailVerifiedAt != nil {
+		t.Fatal("new user should not be verified")
+	}
+
+	// 未验证邮箱时不能发文
+	resp := s.expect(http.StatusForbidden, http.MethodPost, "/api/posts", alice.Token, gin.H{"title": "T", "content": "C"})
+	if resp.Code != apperror.CodeForbidden {
+		t.Errorf("code = %q, want %q", resp.Code, apperror.CodeForbidden)
+	}
+
+	// 重新发送后旧链接失效
+	first := s.mailToken("alice@example.com", "/verify-email")
+	s.expect(http.StatusOK, http.MethodPost, "/api/email/resend", alice.Token, nil)
+	token := s.mailToken("alice@example.com", "/verify-email")
+	if token == first {
+		t.Fatal("resend returned the same token")
+	}
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/email/verify", "", gin.H{"token": first})
+
+	resp = s.expect(http.StatusOK, http.MethodPost, "/api/email/verify", "", gin.H{"token": token})
+	if user := decode[models.User](t, resp); user.EmailVerifiedAt == nil {
+		t.Error("email_verified_at not set")
+	}
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/email/verify", "", gin.H{"token": token})
+	s.expect(http.StatusConflict, http.MethodPost, "/api/email/resend", alice.Token, nil)
+	s.createPost(alice.Token, "Verified", true)
+
+	// 篡改签名或用途不符的令牌无效
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/email/verify", "", gin.H{"token": token + "x"})
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/password/reset", "", gin.H{"token": token, "password": "newpass123"})
+}
+
+func TestPasswordReset(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	// 未注册的邮箱返回相同的响应
+	unknown := s.expect(http.StatusOK, http.MethodPost, "/api/password/forgot", "", gin.H{"email": "nobody@example.com"})
+	known := s.expect(http.StatusOK, http.MethodPost, "/api/password/forgot", "", gin.H{"email": "alice@example.com"})
+	if unknown.Message != known.Message {
+		t.Errorf("responses differ: %q vs %q", unknown.Message, known.Message)
+	}
+
+	token := s.mailToken("alice@example.com", "/reset-password")
+	s.expect(http.StatusOK, http.MethodPost, "/api/password/reset", "", gin.H{"token": token, "password": "newpass123"})
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/password/reset", "", gin.H{"token": token, "password": "another123"})
+
+	// 重置后旧会话全部失效，只能用新密码登录
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me/posts", alice.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/login", "", gin.H{"email": "alice@example.com", "password": "secret123"})
+	resp := s.expect(http.StatusOK, http.MethodPost, "/api/login", "", gin.H{"email": "alice@example.com", "password": "newpass123"})
+	if user := decode[authData](t, resp).User; user.EmailVerifiedAt == nil {
+		t.Error("password reset should mark the email as verified")
+	}
+}
+
+func TestForgotPasswordHidesMailerErrors(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) {
+		cfg.MailDriver = "smtp"
+		cfg.SMTPHost = "127.0.0.1"
+		cfg.SMTPPort = "1"
+	})
+	s.register("alice")
+
+	// 邮件发送失败时已注册的邮箱与未注册的邮箱同样返回成功
+	unknown := s.expect(http.StatusOK, http.MethodPost, "/api/password/forgot", "", gin.H{"email": "nobody@example.com"})
+	known := s.expect(http.StatusOK, http.MethodPost, "/api/password/forgot", "", gin.H{"email": "alice@example.com"})
+	if unknown.Message != known.Message {
+		t.Errorf("responses differ: %q vs %q", unknown.Message, known.Message)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+
+	resp := s.expect(http.StatusOK, http.MethodGet, "/api/me", alice.Token, nil)
+	if me := decode[models.User](t, resp); me.Email != "alice@example.com" || !me.IsActive {
+		t.Errorf("GET /me = %+v", me)
+	}
+
+	resp = s.expect(http.StatusOK, http.MethodPut, "/api/me", alice.Token, gin.H{
+		"nickname": "Alice",
+		"avatar":   "https://example.com/a.png",
+		"bio":      "Gopher",
+	})
+	if me := decode[models.User](t, resp); me.Nickname != "Alice" || me.Bio != "Gopher" {
+		t.Errorf("PUT /me = %+v", me)
+	}
+	// 只修改提供的字段，空字符串表示清空
+	resp = s.expect(http.StatusOK, http.MethodPut, "/api/me", alice.Token, gin.H{"avatar": ""})
+	if me := decode[models.User](t, resp); me.Avatar != "" || me.Nickname != "Alice" {
+		t.Errorf("partial update = %+v", me)
+	}
+	resp = s.expect(http.StatusBadRequest, http.MethodPut, "/api/me", alice.Token, gin.H{"avatar": "javascript:alert(1)"})
+	if len(resp.Details) != 1 || resp.Details[0].Field != "avatar" {
+		t.Errorf("details = %+v", resp.Details)
+	}
+
+	s.createPost(alice.Token, "Public", true)
+	s.createPost(alice.Token, "Draft", false)
+	resp = s.expect(http.StatusOK, http.MethodGet, "/api/users/alice", "", nil)
+	page := decode[struct {
+		User  map[string]interface{} `json:"user"`
+		Posts pageData               `json:"posts"`
+	}](t, resp)
+	if _, ok := page.User["email"]; ok {
+		t.Errorf("public profile leaks email: %v", page.User)
+	}
+	if page.User["nickname"] != "Alice" || page.Posts.Total != 1 || page.Posts.Items[0].Title != "Public" {
+		t.Errorf("user page = %+v", page)
+	}
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/users/nobody", "", nil)
+}
+
+func TestChangePassword(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	login := func(password string) (int, apiResponse) {
+		return s.do(http.MethodPost, "/api/login", "", gin.H{"email": "alice@example.com", "password": password})
+	}
+	_, resp := login("secret123")
+	other := decode[authData](t, resp)
+
+	resp = s.expect(http.StatusBadRequest, http.MethodPut, "/api/me/password", alice.Token, gin.H{
+		"current_password": "wrong-password",
+		"new_password":     "newpass123",
+	})
+	if len(resp.Details) != 1 || resp.Details[0].Field != "current_password" {
+		t.Errorf("details = %+v", resp.Details)
+	}
+
+	s.expect(http.StatusOK, http.MethodPut, "/api/me/password", alice.Token, gin.H{
+		"current_password": "secret123",
+		"new_password":     "newpass123",
+	})
+
+	// 当前会话保留，其他会话失效
+	s.expect(http.StatusOK, http.MethodGet, "/api/me", alice.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", other.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/token/refresh", "", gin.H{"refresh_token": other.RefreshToken})
+	if status, _ := login("secret123"); status != http.StatusUnauthorized {
+		t.Errorf("login with old password: status = %d", status)
+	}
+	if status, _ := login("newpass123"); status != http.StatusOK {
+		t.Errorf("login with new password: status = %d", status)
+	}
+}
+
+func TestDeactivateAndDeleteAccount(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.AdminEmail = "admin@example.com" })
+	admin := s.register("admin")
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/me/deactivate", alice.Token, gin.H{"password": "wrong-password"})
+	s.expect(http.StatusOK, http.MethodPost, "/api/me/deactivate", alice.Token, gin.H{"password": "secret123"})
+
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/users/alice", "", nil)
+	resp := s.expect(http.StatusForbidden, http.MethodPost, "/api/login", "", gin.H{"email": "alice@example.com", "password": "secret123"})
+	if resp.Message != "Account is deactivated" {
+		t.Errorf("message = %q", resp.Message)
+	}
+
+	// 管理员重新启用后可以登录
+	s.expect(http.StatusForbidden, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/status", alice.User.ID), bob.Token, gin.H{"is_active": true})
+	s.expect(http.StatusOK, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/status", alice.User.ID), admin.Token, gin.H{"is_active": true})
+	s.expect(http.StatusOK, http.MethodPost, "/api/login", "", gin.H{"email": "alice@example.com", "password": "secret123"})
+	s.expect(http.StatusBadRequest, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/status", admin.User.ID), admin.Token, gin.H{"is_active": false})
+
+	s.expect(http.StatusOK, http.MethodDelete, "/api/me", bob.Token, gin.H{"password": "secret123"})
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", bob.Token, nil)
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/login", "", gin.H{"email": "bob@example.com", "password": "secret123"})
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/users/bob", "", nil)
+
+	// 注销后原用户名和邮箱可以重新注册为新账号，停用的账号仍然占用
+	again := s.register("bob")
+	if again.User.ID == bob.User.ID {
+		t.Errorf("re-registered bob reuses id %d", bob.User.ID)
+	}
+	s.expect(http.StatusOK, http.MethodGet, "/api/users/bob", "", nil)
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/me", bob.Token, nil)
+	s.expect(http.StatusOK, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/status", alice.User.ID), admin.Token, gin.H{"is_active": false})
+	s.expect(http.StatusConflict, http.MethodPost, "/api/register", "", gin.H{"username": "alice", "email": "alice2@example.com", "password": "secret123"})
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("/api/admin/users/%d", alice.User.ID), admin.Token, nil)
+	s.register("alice")
+
+	// 注销账号改写后的用户名保留，不能注册
+	resp = s.expect(http.StatusBadRequest, http.MethodPost, "/api/register", "", gin.H{
+		"username": models.DeletedUsernamePrefix + "1",
+		"email":    "squatter@example.com",
+		"password": "secret123",
+	})
+	if resp.Code != apperror.CodeValidationFailed || len(resp.Details) != 1 || resp.Details[0].Field != "username" {
+		t.Errorf("reserved username response = %+v", resp)
+	}
+}
+
+func TestCounters(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.AdminEmail = "admin@example.com" })
+	admin := s.register("admin")
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	post := s.createPost(alice.Token, "Counted", true)
+	s.createPost(alice.Token, "Draft", false)
+	postPath := fmt.Sprintf("/api/posts/%d", post.ID)
+	commentsPath := fmt.Sprintf("/api/post-comments/%d/comments", post.ID)
+
+	var comments []models.Comment
+	for _, token := range []string{alice.Token, bob.Token, bob.Token} {
+		resp := s.expect(http.StatusCreated, http.MethodPost, commentsPath, token, gin.H{"content": "hello"})
+		comments = append(comments, decode[models.Comment](t, resp))
+	}
+	counts := func() (int64, int64) {
+		t.Helper()
+		got := decode[models.Post](t, s.expect(http.StatusOK, http.MethodGet, postPath, "", nil))
+		me := decode[models.User](t, s.expect(http.StatusOK, http.MethodGet, "/api/me", alice.Token, nil))
+		return got.CommentCount, me.PostCount
+	}
+	if comment, posts := counts(); comment != 3 || posts != 2 {
+		t.Fatalf("counts = %d comments / %d posts, want 3 / 2", comment, posts)
+	}
+
+	commentPath := fmt.Sprintf("%s/%d", commentsPath, comments[1].ID)
+	s.expect(http.StatusOK, http.MethodDelete, commentPath, bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodDelete, commentPath, bob.Token, nil)
+	if comment, _ := counts(); comment != 2 {
+		t.Errorf("comment_count after delete = %d, want 2", comment)
+	}
+
+	// 只有版主/管理员可以恢复，且只能恢复已删除的记录
+	s.expect(http.StatusForbidden, http.MethodPost, commentPath+"/restore", bob.Token, nil)
+	s.expect(http.StatusOK, http.MethodPost, commentPath+"/restore", admin.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPost, commentPath+"/restore", admin.Token, nil)
+	if comment, _ := counts(); comment != 3 {
+		t.Errorf("comment_count after restore = %d, want 3", comment)
+	}
+
+	s.expect(http.StatusOK, http.MethodDelete, postPath, alice.Token, nil)
+	me := decode[models.User](t, s.expect(http.StatusOK, http.MethodGet, "/api/me", alice.Token, nil))
+	if me.PostCount != 1 {
+		t.Errorf("post_count after delete = %d, want 1", me.PostCount)
+	}
+	s.expect(http.StatusForbidden, http.MethodPost, postPath+"/restore", alice.Token, nil)
+	s.expect(http.StatusOK, http.MethodPost, postPath+"/restore", admin.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPost, postPath+"/restore", admin.Token, nil)
+	if comment, posts := counts(); comment != 3 || posts != 2 {
+		t.Errorf("counts after restore = %d comments / %d posts, want 3 / 2", comment, posts)
+	}
+
+	resp := s.expect(http.StatusOK, http.MethodGet, "/api/users/alice", "", nil)
+	page := decode[struct {
+		User models.PublicProfile `json:"user"`
+	}](t, resp)
+	if page.User.PostCount != 2 {
+		t.Errorf("public post_count = %d, want 2", page.User.PostCount)
+	}
+}
+
+func TestReconcileCounters(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	post := s.createPost(alice.Token, "Post", true)
+	s.expect(http.StatusCreated, http.MethodPost, fmt.Sprintf("/api/post-comments/%d/comments", post.ID), alice.Token, gin.H{"content": "hi"})
+
+	ctx := context.Background()
+	counters := repository.NewCounterRepository(s.db)
+	if drifts, err := counters.Drift(ctx); err != nil || len(drifts) != 0 {
+		t.Fatalf("Drift() = %+v, %v; want no drift", drifts, err)
+	}
+
+	// 模拟计数漂移：绕过仓储直接修改
+	s.db.Model(&models.Post{}).Where("id = ?", post.ID).UpdateColumn("comment_count", 7)
+	s.db.Model(&models.User{}).Where("id = ?", alice.User.ID).UpdateColumn("post_count", 0)
+
+	drifts, err := counters.Drift(ctx)
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	want := []repository.CounterDrift{
+		{Table: "posts", Column: "comment_count", ID: post.ID, Stored: 7, Actual: 1},
+		{Table: "users", Column: "post_count", ID: alice.User.ID, Stored: 0, Actual: 1},
+	}
+	if !reflect.DeepEqual(drifts, want) {
+		t.Fatalf("Drift() = %+v, want %+v", drifts, want)
+	}
+
+	if err := counters.Repair(ctx, drifts); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+	if drifts, err := counters.Drift(ctx); err != nil || len(drifts) != 0 {
+		t.Errorf("Drift() after repair = %+v, %v; want no drift", drifts, err)
+	}
+}
+
+func TestViewsAndTrending(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	viewed := s.createPost(alice.Token, "Viewed", true)
+	discussed := s.createPost(alice.Token, "Discussed", true)
+	draft := s.createPost(alice.Token, "Draft", false)
+	viewedPath := fmt.Sprintf("/api/posts/%d", viewed.ID)
+
+	// 作者本人和草稿不计数，同一访客重复浏览只计一次
+	s.expect(http.StatusOK, http.MethodGet, viewedPath, alice.Token, nil)
+	s.expect(http.StatusOK, http.MethodGet, fmt.Sprintf("/api/posts/%d", draft.ID), alice.Token, nil)
+	for i := 0; i < 3; i++ {
+		s.expect(http.StatusOK, http.MethodGet, viewedPath, bob.Token, nil)
+		s.expect(http.StatusOK, http.MethodGet, viewedPath, "", nil)
+	}
+	if n, err := s.tracker.Flush(context.Background()); err != nil || n != 2 {
+		t.Fatalf("Flush() = %d, %v; want 2", n, err)
+	}
+	got := decode[models.Post](t, s.expect(http.StatusOK, http.MethodGet, viewedPath, "", nil))
+	if got.ViewCount != 2 {
+		t.Errorf("view_count = %d, want 2", got.ViewCount)
+	}
+
+	for i := 0; i < 2; i++ {
+		s.expect(http.StatusCreated, http.MethodPost, fmt.Sprintf("/api/post-comments/%d/comments", discussed.ID), bob.Token, gin.H{"content": "nice"})
+	}
+
+	resp := s.expect(http.StatusOK, http.MethodGet, "/api/posts/trending?window=24h", "", nil)
+	trending := decode[[]struct {
+		Post           models.Post `json:"post"`
+		Score          float64     `json:"score"`
+		RecentViews    int64       `json:"recent_views"`
+		RecentComments int64       `json:"recent_comments"`
+	}](t, resp)
+	if len(trending) != 2 {
+		t.Fatalf("trending = %+v, want 2 posts", trending)
+	}
+	// 评论的权重高于浏览
+	if trending[0].Post.ID != discussed.ID || trending[0].RecentComments != 2 ||
+		trending[1].Post.ID != viewed.ID || trending[1].RecentViews != 2 {
+		t.Errorf("trending = %+v", trending)
+	}
+	if trending[0].Score <= trending[1].Score {
+		t.Errorf("scores = %v, %v; want descending", trending[0].Score, trending[1].Score)
+	}
+
+	resp = s.expect(http.StatusOK, http.MethodGet, "/api/posts/trending?limit=1", "", nil)
+	if got := decode[[]json.RawMessage](t, resp); len(got) != 1 {
+		t.Errorf("limit=1 returned %d posts", len(got))
+	}
+	for _, query := range []string{"window=10m", "window=30d", "window=abc", "limit=0", "limit=100"} {
+		s.expect(http.StatusBadRequest, http.MethodGet, "/api/posts/trending?"+query, "", nil)
+	}
+
+	// 删除的文章不再出现在热门列表中
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("/api/posts/%d", discussed.ID), alice.Token, nil)
+	resp = s.expect(http.StatusOK, http.MethodGet, "/api/posts/trending", "", nil)
+	if got := decode[[]struct {
+		Post models.Post `json:"post"`
+	}](t, resp); len(got) != 1 || got[0].Post.ID != viewed.ID {
+		t.Errorf("trending after delete = %+v", got)
+	}
+}
+
+func TestTrendingDecay(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	old := s.createPost(alice.Token, "Old", true)
+	fresh := s.createPost(alice.Token, "Fresh", true)
+	stale := s.createPost(alice.Token, "Stale", true)
+
+	// 半衰期为 6 小时，12 小时前的 8 次浏览只相当于现在的 2 次；窗口外的浏览不计入
+	now := time.Now()
+	stats := []models.PostViewStat{
+		{PostID: old.ID, Bucket: now.Add(-12 * time.Hour), Views: 8},
+		{PostID: fresh.ID, Bucket: now, Views: 3},
+		{PostID: stale.ID, Bucket: now.Add(-30 * time.Hour), Views: 100},
+	}
+	if err := s.db.Create(&stats).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	trending := decode[[]struct {
+		Post        models.Post `json:"post"`
+		Score       float64     `json:"score"`
+		RecentViews int64       `json:"recent_views"`
+	}](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts/trending?window=24h", "", nil))
+	if len(trending) != 2 || trending[0].Post.ID != fresh.ID || trending[1].Post.ID != old.ID {
+		t.Fatalf("trending = %+v, want Fresh then Old", trending)
+	}
+	if got := trending[1]; got.RecentViews != 8 || math.Abs(got.Score-2) > 0.01 {
+		t.Errorf("old post = %d views scored %v, want 8 views scored 2", got.RecentViews, got.Score)
+	}
+	if got := trending[0]; got.RecentViews != 3 || math.Abs(got.Score-3) > 0.01 {
+		t.Errorf("fresh post = %d views scored %v, want 3", got.RecentViews, got.Score)
+	}
+}
+
+func TestReactions(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	post := s.createPost(alice.Token, "Reactable", true)
+	draft := s.createPost(alice.Token, "Draft", false)
+	path := fmt.Sprintf("/api/posts/%d/reactions", post.ID)
+	summary := func(resp apiResponse) map[string]models.ReactionSummary {
+		t.Helper()
+		result := make(map[string]models.ReactionSummary)
+		for _, r := range decode[[]models.ReactionSummary](t, resp) {
+			result[r.Type] = r
+		}
+		return result
+	}
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, path, "", gin.H{"type": "like"})
+	resp := s.expect(http.StatusBadRequest, http.MethodPost, path, bob.Token, gin.H{"type": "meh"})
+	if len(resp.Details) != 1 || resp.Details[0].Field != "type" {
+		t.Errorf("details = %+v", resp.Details)
+	}
+	s.expect(http.StatusNotFound, http.MethodPost, fmt.Sprintf("/api/posts/%d/reactions", draft.ID), bob.Token, gin.H{"type": "like"})
+	s.expect(http.StatusBadRequest, http.MethodPost, fmt.Sprintf("/api/posts/%d/reactions", draft.ID), alice.Token, gin.H{"type": "like"})
+
+	s.expect(http.StatusCreated, http.MethodPost, path, bob.Token, gin.H{"type": "like"})
+	s.expect(http.StatusCreated, http.MethodPost, path, bob.Token, gin.H{"type": "love"})
+	got := summary(s.expect(http.StatusCreated, http.MethodPost, path, alice.Token, gin.H{"type": "like"}))
+	if got["like"].Count != 2 || !got["like"].Reacted || got["love"].Count != 1 || got["love"].Reacted {
+		t.Errorf("summary for alice = %+v", got)
+	}
+	// 同一用户对同一类型只能表态一次
+	s.expect(http.StatusConflict, http.MethodPost, path, bob.Token, gin.H{"type": "like"})
+
+	// 详情和列表中都包含表态汇总，并标记当前用户已留下的表态
+	detail := decode[models.Post](t, s.expect(http.StatusOK, http.MethodGet, fmt.Sprintf("/api/posts/%d", post.ID), bob.Token, nil))
+	if len(detail.Reactions) != len(models.ReactionTypes) {
+		t.Fatalf("reactions = %+v, want every type", detail.Reactions)
+	}
+	for _, r := range detail.Reactions {
+		wantReacted := r.Type == "like" || r.Type == "love"
+		if r.Reacted != wantReacted {
+			t.Errorf("bob reacted %s = %v, want %v", r.Type, r.Reacted, wantReacted)
+		}
+	}
+	list := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts", "", nil))
+	for _, item := range list.Items {
+		for _, r := range item.Reactions {
+			if r.Reacted {
+				t.Errorf("anonymous list marks %s as reacted on post %d", r.Type, item.ID)
+			}
+			if item.ID == post.ID && r.Type == "like" && r.Count != 2 {
+				t.Errorf("list like count = %d, want 2", r.Count)
+			}
+		}
+	}
+	list = decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/posts", alice.Token, nil))
+	if r := list.Items[0].Reactions[0]; list.Items[0].ID != post.ID || r.Type != "like" || !r.Reacted {
+		t.Errorf("alice list reactions = %+v", list.Items[0].Reactions)
+	}
+
+	got = summary(s.expect(http.StatusOK, http.MethodDelete, path+"?type=like", bob.Token, nil))
+	if got["like"].Count != 1 || got["like"].Reacted || !got["love"].Reacted {
+		t.Errorf("summary after delete = %+v", got)
+	}
+	s.expect(http.StatusNotFound, http.MethodDelete, path+"?type=like", bob.Token, nil)
+	s.expect(http.StatusBadRequest, http.MethodDelete, path, bob.Token, nil)
+}
+
+func TestBookmarks(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	first := s.createPost(alice.Token, "First", true)
+	second := s.createPost(alice.Token, "Second", true)
+	draft := s.createPost(alice.Token, "Draft", false)
+	bookmark := func(id uint) string { return fmt.Sprintf("/api/posts/%d/bookmark", id) }
+	bookmarked := func(path string) []string {
+		t.Helper()
+		page := decode[struct {
+			Items []models.Bookmark `json:"items"`
+		}](t, s.expect(http.StatusOK, http.MethodGet, path, bob.Token, nil))
+		result := make([]string, len(page.Items))
+		for i, item := range page.Items {
+			result[i] = item.Post.Title
+		}
+		return result
+	}
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, bookmark(first.ID), "", nil)
+	s.expect(http.StatusNotFound, http.MethodPost, bookmark(draft.ID), bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPost, bookmark(9999), bob.Token, nil)
+
+	created := decode[models.Bookmark](t, s.expect(http.StatusCreated, http.MethodPost, bookmark(first.ID), bob.Token, nil))
+	if created.PostID != first.ID || created.Post.Title != "First" {
+		t.Errorf("bookmark = %+v", created)
+	}
+	s.expect(http.StatusConflict, http.MethodPost, bookmark(first.ID), bob.Token, nil)
+	s.expect(http.StatusCreated, http.MethodPost, bookmark(second.ID), bob.Token, nil)
+
+	// 最近收藏的排在前面，收藏只对本人可见
+	if got := bookmarked("/api/me/bookmarks"); !slices.Equal(got, []string{"Second", "First"}) {
+		t.Errorf("bookmarks = %v", got)
+	}
+	if got := bookmarked("/api/me/bookmarks?limit=1"); !slices.Equal(got, []string{"Second"}) {
+		t.Errorf("first page = %v", got)
+	}
+	mine := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/me/bookmarks", alice.Token, nil))
+	if len(mine.Items) != 0 {
+		t.Errorf("alice sees %d bookmarks, want 0", len(mine.Items))
+	}
+
+	// 文章删除后不再出现在收藏中，但仍然可以取消收藏
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("/api/posts/%d", second.ID), alice.Token, nil)
+	if got := bookmarked("/api/me/bookmarks"); !slices.Equal(got, []string{"First"}) {
+		t.Errorf("bookmarks after delete = %v", got)
+	}
+	s.expect(http.StatusOK, http.MethodDelete, bookmark(second.ID), bob.Token, nil)
+
+	s.expect(http.StatusOK, http.MethodDelete, bookmark(first.ID), bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodDelete, bookmark(first.ID), bob.Token, nil)
+	if got := bookmarked("/api/me/bookmarks"); len(got) != 0 {
+		t.Errorf("bookmarks after remove = %v", got)
+	}
+}
+
+func TestReadingLists(t *testing.T) {
+	s := newTestServer(t)
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	first := s.createPost(bob.Token, "First", true)
+	second := s.createPost(bob.Token, "Second", true)
+	third := s.createPost(alice.Token, "Third", true)
+	draft := s.createPost(alice.Token, "Draft", false)
+	itemTitles := func(list models.ReadingList) []string {
+		result := make([]string, len(list.Items))
+		for i, item := range list.Items {
+			result[i] = item.Post.Title
+		}
+		return result
+	}
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, "/api/me/lists", "", gin.H{"name": "Go"})
+	s.expect(http.StatusBadRequest, http.MethodPost, "/api/me/lists", alice.Token, gin.H{"name": ""})
+	list := decode[models.ReadingList](t, s.expect(http.StatusCreated, http.MethodPost, "/api/me/lists", alice.Token, gin.H{
+		"name": "Go Concurrency", "description": "Channels and friends",
+	}))
+	if !strings.HasPrefix(list.Slug, "go-concurrency-") || list.IsPublic {
+		t.Errorf("list = %+v", list)
+	}
+	path := fmt.Sprintf("/api/me/lists/%d", list.ID)
+	public := "/api/lists/" + list.Slug
+
+	// 列表默认不公开，其他用户也无法访问
+	s.expect(http.StatusNotFound, http.MethodGet, public, "", nil)
+	s.expect(http.StatusNotFound, http.MethodGet, path, bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPost, path+"/items", bob.Token, gin.H{"post_id": first.ID})
+
+	s.expect(http.StatusCreated, http.MethodPost, path+"/items", alice.Token, gin.H{"post_id": first.ID})
+	s.expect(http.StatusCreated, http.MethodPost, path+"/items", alice.Token, gin.H{"post_id": second.ID})
+	list = decode[models.ReadingList](t, s.expect(http.StatusCreated, http.MethodPost, path+"/items", alice.Token, gin.H{"post_id": third.ID}))
+	if got := itemTitles(list); !slices.Equal(got, []string{"First", "Second", "Third"}) {
+		t.Errorf("items = %v", got)
+	}
+	s.expect(http.StatusConflict, http.MethodPost, path+"/items", alice.Token, gin.H{"post_id": first.ID})
+	s.expect(http.StatusNotFound, http.MethodPost, path+"/items", alice.Token, gin.H{"post_id": draft.ID})
+
+	list = decode[models.ReadingList](t, s.expect(http.StatusOK, http.MethodPut, path+"/items/order", alice.Token, gin.H{
+		"post_ids": []uint{third.ID, first.ID},
+	}))
+	if got := itemTitles(list); !slices.Equal(got, []string{"Third", "First", "Second"}) {
+		t.Errorf("reordered items = %v", got)
+	}
+	resp := s.expect(http.StatusBadRequest, http.MethodPut, path+"/items/order", alice.Token, gin.H{"post_ids": []uint{draft.ID}})
+	if len(resp.Details) != 1 || resp.Details[0].Field != "post_ids" {
+		t.Errorf("details = %+v", resp.Details)
+	}
+
+	lists := decode[[]models.ReadingList](t, s.expect(http.StatusOK, http.MethodGet, "/api/me/lists", alice.Token, nil))
+	if len(lists) != 1 || lists[0].ItemCount != 3 {
+		t.Errorf("lists = %+v", lists)
+	}
+
+	// 公开后任何人都可以通过 slug 访问，改名不影响 slug，已删除的文章不再显示
+	updated := decode[models.ReadingList](t, s.expect(http.StatusOK, http.MethodPut, path, alice.Token, gin.H{"name": "Renamed", "is_public": true}))
+	if updated.Slug != list.Slug || updated.Name != "Renamed" || !updated.IsPublic {
+		t.Errorf("updated = %+v", updated)
+	}
+	s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("/api/posts/%d", second.ID), bob.Token, nil)
+	shared := decode[models.ReadingList](t, s.expect(http.StatusOK, http.MethodGet, public, "", nil))
+	if shared.Owner == nil || shared.Owner.Username != "alice" {
+		t.Errorf("owner = %+v", shared.Owner)
+	}
+	if got := itemTitles(shared); !slices.Equal(got, []string{"Third", "First"}) {
+		t.Errorf("public items = %v", got)
+	}
+
+	list = decode[models.ReadingList](t, s.expect(http.StatusOK, http.MethodDelete, fmt.Sprintf("%s/items/%d", path, third.ID), alice.Token, nil))
+	if got := itemTitles(list); !slices.Equal(got, []string{"First"}) {
+		t.Errorf("items after remove = %v", got)
+	}
+	s.expect(http.StatusNotFound, http.MethodDelete, fmt.Sprintf("%s/items/%d", path, third.ID), alice.Token, nil)
+
+	s.expect(http.StatusNotFound, http.MethodDelete, path, bob.Token, nil)
+	s.expect(http.StatusOK, http.MethodDelete, path, alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodGet, path, alice.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodGet, public, "", nil)
+}
+
+func TestFollowsAndFeed(t *testing.T) {
+	s := newTestServer(t, func(cfg *config.Config) { cfg.AdminEmail = "admin@example.com" })
+	admin := s.register("admin")
+	alice := s.register("alice")
+	bob := s.register("bob")
+	carol := s.register("carol")
+	dave := s.register("dave")
+	follow := func(name string) string { return "/api/users/" + name + "/follow" }
+	usernames := func(path string) []string {
+		t.Helper()
+		page := decode[struct {
+			Items []models.FollowProfile `json:"items"`
+		}](t, s.expect(http.StatusOK, http.MethodGet, path, "", nil))
+		result := make([]string, len(page.Items))
+		for i, item := range page.Items {
+			result[i] = item.Username
+		}
+		return result
+	}
+
+	s.expect(http.StatusUnauthorized, http.MethodPost, follow("alice"), "", nil)
+	s.expect(http.StatusBadRequest, http.MethodPost, follow("bob"), bob.Token, nil)
+	s.expect(http.StatusNotFound, http.MethodPost, follow("nobody"), bob.Token, nil)
+
+	profile := decode[models.PublicProfile](t, s.expect(http.StatusCreated, http.MethodPost, follow("alice"), bob.Token, nil))
+	if profile.Username != "alice" || profile.FollowerCount != 1 {
+		t.Errorf("followed profile = %+v", profile)
+	}
+	s.expect(http.StatusConflict, http.MethodPost, follow("alice"), bob.Token, nil)
+	s.expect(http.StatusCreated, http.MethodPost, follow("carol"), bob.Token, nil)
+	s.expect(http.StatusCreated, http.MethodPost, follow("alice"), carol.Token, nil)
+
+	page := decode[struct {
+		User models.PublicProfile `json:"user"`
+	}](t, s.expect(http.StatusOK, http.MethodGet, "/api/users/bob", "", nil))
+	if page.User.FollowingCount != 2 || page.User.FollowerCount != 0 {
+		t.Errorf("bob counts = %d following / %d followers, want 2/0", page.User.FollowingCount, page.User.FollowerCount)
+	}
+	if got := usernames("/api/users/alice/followers"); !slices.Equal(got, []string{"carol", "bob"}) {
+		t.Errorf("alice followers = %v", got)
+	}
+	if got := usernames("/api/users/bob/following"); !slices.Equal(got, []string{"carol", "alice"}) {
+		t.Errorf("bob following = %v", got)
+	}
+	s.expect(http.StatusNotFound, http.MethodGet, "/api/users/nobody/followers", "", nil)
+
+	// 动态只包含关注的作者已发布的文章，最新的在前
+	s.createPost(alice.Token, "A1", true)
+	s.createPost(carol.Token, "C1", true)
+	s.createPost(alice.Token, "A2", true)
+	s.createPost(alice.Token, "Draft", false)
+	s.createPost(dave.Token, "D1", true)
+
+	s.expect(http.StatusUnauthorized, http.MethodGet, "/api/feed", "", nil)
+	first := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/feed?limit=2", bob.Token, nil))
+	if got := titles(first.Items); !slices.Equal(got, []string{"A2", "C1"}) || !first.HasNext || first.Total != 3 {
+		t.Fatalf("first page = %v has_next=%v total=%d", got, first.HasNext, first.Total)
+	}
+	next := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/feed?limit=2&cursor="+first.NextCursor, bob.Token, nil))
+	if got := titles(next.Items); !slices.Equal(got, []string{"A1"}) || next.HasNext {
+		t.Errorf("second page = %v has_next=%v", got, next.HasNext)
+	}
+	empty := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/feed", dave.Token, nil))
+	if len(empty.Items) != 0 || empty.Total != 0 {
+		t.Errorf("dave feed = %v, want empty", titles(empty.Items))
+	}
+
+	// 取消关注后动态立即更新，不等缓存过期
+	profile = decode[models.PublicProfile](t, s.expect(http.StatusOK, http.MethodDelete, follow("carol"), bob.Token, nil))
+	if profile.FollowerCount != 0 {
+		t.Errorf("carol follower_count = %d, want 0", profile.FollowerCount)
+	}
+	s.expect(http.StatusNotFound, http.MethodDelete, follow("carol"), bob.Token, nil)
+	feed := decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/feed", bob.Token, nil))
+	if got := titles(feed.Items); !slices.Equal(got, []string{"A2", "A1"}) {
+		t.Errorf("feed after unfollow = %v", got)
+	}
+	s.expect(http.StatusCreated, http.MethodPost, follow("dave"), bob.Token, nil)
+	feed = decode[pageData](t, s.expect(http.StatusOK, http.MethodGet, "/api/feed", bob.Token, nil))
+	if got := titles(feed.Items); !slices.Equal(got, []string{"D1", "A2", "A1"}) {
+		t.Errorf("feed after follow = %v", got)
+	}
+
+	// 停用和删除的账号既不出现在关注列表中，也不计入粉丝数和关注数
+	counts := func(name string) (int64, int64) {
+		t.Helper()
+		page := decode[struct {
+			User models.PublicProfile `json:"user"`
+		}](t, s.expect(http.StatusOK, http.MethodGet, "/api/users/"+name, "", nil))
+		return page.User.FollowerCount, page.User.FollowingCount
+	}
+	noDrift := func() {
+		t.Helper()
+		if drifts, err := repository.NewCounterRepository(s.db).Drift(context.Background()); err != nil || len(drifts) != 0 {
+			t.Errorf("Drift() = %+v, %v; want no drift", drifts, err)
+		}
+	}
+	s.expect(http.StatusOK, http.MethodPost, "/api/me/deactivate", carol.Token, gin.H{"password": "secret123"})
+	if got := usernames("/api/users/alice/followers"); !slices.Equal(got, []string{"bob"}) {
+		t.Errorf("alice followers after deactivation = %v", got)
+	}
+	if followers, _ := counts("alice"); followers != 1 {
+		t.Errorf("alice follower_count after deactivation = %d, want 1", followers)
+	}
+	s.expect(http.StatusNotFound, http.MethodPost, follow("carol"), bob.Token, nil)
+	noDrift()
+
+	// 重新启用后恢复计数，重复启用不会重复计算
+	status := fmt.Sprintf("/api/admin/users/%d/status", carol.User.ID)
+	s.expect(http.StatusOK, http.MethodPut, status, admin.Token, gin.H{"is_active": true})
+	s.expect(http.StatusOK, http.MethodPut, status, admin.Token, gin.H{"is_active": true})
+	if followers, _ := counts("alice"); followers != 2 {
+		t.Errorf("alice follower_count after reactivation = %d, want 2", followers)
+	}
+	noDrift()
+
+	// 取消关注已停用的用户只调整对方的粉丝数，关注者的关注数在停用时已经扣除
+	s.expect(http.StatusOK, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/status", alice.User.ID), admin.Token, gin.H{"is_active": false})
+	if _, following := counts("bob"); following != 1 {
+		t.Errorf("bob following_count after alice deactivated = %d, want 1", following)
+	}
+	s.expect(http.StatusOK, http.MethodDelete, follow("alice"), bob.Token, nil)
+	if _, following := counts("bob"); following != 1 {
+		t.Errorf("bob following_count after unfollowing alice = %d, want 1", following)
+	}
+	noDrift()
+
+	s.expect(http.StatusOK, http.MethodDelete, "/api/me", dave.Token, gin.H{"password": "secret123"})
+	if got := usernames("/api/users/bob/following"); len(got) != 0 {
+		t.Errorf("bob following after dave deleted = %v", got)
+	}
+	if _, following := counts("bob"); following != 0 {
+		t.Errorf("bob following_count after dave deleted = %d, want 0", following)
+	}
+	noDrift()
+}
+
+func titles(posts []models.Post) []string {
+	result := make([]string, len(posts))
+	for i, post := range posts {
+		result[i] = post.Title
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Publisher 发布到达 publish_at 的草稿，由 repository.PostRepository 实现。
+type Publisher interface {
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+}
+
+// StartPublisher 周期性地把到达 publish_at 的草稿发布出去，ctx 取消后退出。
+func StartPublisher(ctx context.Context, publisher Publisher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := publisher.PublishDue(ctx, time.Now()); err != nil {
+			slog.Error("failed to publish scheduled posts", "error", err)
+		} else if n > 0 {
+			slog.Info("published scheduled posts", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+const (
+	titleWeight   = 3.0
+	contentWeight = 1.0
+	commentWeight = 0.5
+)
+
+type commentEntry struct {
+	postID uint
+	terms  map[string]float64
+}
+
+// memoryEngine 是进程内的倒排索引，在文章、评论增删改时同步维护。
+// 索引包含所有状态的文章，搜索时再到数据库过滤出已发布的文章，
+// 这样定时发布等直接修改状态的操作不需要通知索引。
+type memoryEngine struct {
+	db *gorm.DB
+
+	mu           sync.RWMutex
+	postTerms    map[uint]map[string]float64
+	postIndex    map[string]map[uint]float64
+	comments     map[uint]commentEntry
+	commentIndex map[string]map[uint]float64
+}
+
+func newMemoryEngine(db *gorm.DB) *memoryEngine {
+	return &memoryEngine{
+		db:           db,
+		postTerms:    make(map[uint]map[string]float64),
+		postIndex:    make(map[string]map[uint]float64),
+		comments:     make(map[uint]commentEntry),
+		commentIndex: make(map[string]map[uint]float64),
+	}
+}
+
+func (e *memoryEngine) rebuild() error {
+	var posts []models.Post
+	if err := e.db.Select("id", "title", "content").Find(&posts).Error; err != nil {
+		return err
+	}
+	for i := range posts {
+		e.IndexPost(&posts[i])
+	}
+
+	var comments []models.Comment
+	if err := e.db.Select("id", "post_id", "content").Find(&comments).Error; err != nil {
+		return err
+	}
+	for i := range comments {
+		e.IndexComment(&comments[i])
+	}
+	return nil
+}
+
+func weighTerms(terms map[string]float64, text string, weight float64) {
+	for _, token := range Tokenize(text) {
+		terms[token] += weight
+	}
+}
+
+func (e *memoryEngine) IndexPost(post *models.Post) {
+	terms := make(map[string]float64)
+	weighTerms(terms, post.Title, titleWeight)
+	weighTerms(terms, post.Content, contentWeight)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.removePostLocked(post.ID)
+	e.postTerms[post.ID] = terms
+	for term, tf := range terms {
+		if e.postIndex[term] == nil {
+			e.postIndex[term] = make(map[uint]float64)
+		}
+		e.postIndex[term][post.ID] = tf
+	}
+}
+
+func (e *memoryEngine) RemovePost(postID uint) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.removePostLocked(postID)
+}
+
+func (e *memoryEngine) removePostLocked(postID uint) {
+	for term := range e.postTerms[postID] {
+		delete(e.postIndex[term], postID)
+		if len(e.postIndex[term]) == 0 {
+			delete(e.postIndex, term)
+		}
+	}
+	delete(e.postTerms, postID)
+}
+
+func (e *memoryEngine) IndexComment(comment *models.Comment) {
+	terms := make(map[string]float64)
+	weighTerms(terms, comment.Content, commentWeight)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.removeCommentLocked(comment.ID)
+	e.comments[comment.ID] = commentEntry{postID: comment.PostID, terms: terms}
+	for term, tf := range terms {
+		if e.commentIndex[term] == nil {
+			e.commentIndex[term] = make(map[uint]float64)
+		}
+		e.commentIndex[term][comment.PostID] += tf
+	}
+}
+
+func (e *memoryEngine) RemoveComment(commentID uint) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.removeCommentLocked(commentID)
+}
+
+func (e *memoryEngine) removeCommentLocked(commentID uint) {
+	entry, ok := e.comments[commentID]
+	if !ok {
+		return
+	}
+	for term, tf := range entry.terms {
+		postings := e.commentIndex[term]
+		postings[entry.postID] -= tf
+		if postings[entry.postID] <= 0 {
+			delete(postings, entry.postID)
+		}
+		if len(postings) == 0 {
+			delete(e.commentIndex, term)
+		}
+	}
+	delete(e.comments, commentID)
+}
+
+// Search 使用 TF-IDF 打分：每个查询词的权重词频乘以 log(1 + N/df)。
+func (e *memoryEngine) Search(q Query) ([]Hit, int64, error) {
+	terms := Tokenize(q.Text)
+
+	e.mu.RLock()
+	total := float64(len(e.postTerms))
+	scores := make(map[uint]float64)
+	for _, term := range terms {
+		postings := e.postIndex[term]
+		var commentPostings map[uint]float64
+		if q.IncludeComments {
+			commentPostings = e.commentIndex[term]
+		}
+		df := float64(len(postings) + len(commentPostings))
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + total/df)
+		for postID, tf := range postings {
+			scores[postID] += tf * idf
+		}
+		for postID, tf := range commentPostings {
+			scores[postID] += tf * idf
+		}
+	}
+	e.mu.RUnlock()
+
+	if len(scores) == 0 {
+		return []Hit{}, 0, nil
+	}
+
+	ids := make([]uint, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	var published []uint
+	if err := e.db.Model(&models.Post{}).
+		Where("id IN ? AND status = ?", ids, models.PostStatusPublished).
+		Pluck("id", &published).Error; err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]Hit, 0, len(published))
+	for _, id := range published {
+		hits = append(hits, Hit{PostID: id, Score: scores[id]})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].PostID > hits[j].PostID
+	})
+
+	count := int64(len(hits))
+	if q.Offset >= len(hits) {
+		return []Hit{}, count, nil
+	}
+	hits = hits[q.Offset:]
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, count, nil
+}
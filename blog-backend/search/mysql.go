@@ -0,0 +1,62 @@
+package search
+
+import (
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+// mysqlEngine 直接使用 MySQL FULLTEXT 索引（ngram 分词以支持中文），索引由迁移创建。
+type mysqlEngine struct {
+	db *gorm.DB
+}
+
+func (e *mysqlEngine) Search(q Query) ([]Hit, int64, error) {
+	score := "MATCH(posts.title, posts.content) AGAINST (? IN NATURAL LANGUAGE MODE)"
+	args := []interface{}{q.Text}
+	where := "MATCH(posts.title, posts.content) AGAINST (? IN NATURAL LANGUAGE MODE)"
+	whereArgs := []interface{}{q.Text}
+
+	if q.IncludeComments {
+		commentScore := "(SELECT COALESCE(SUM(MATCH(comments.content) AGAINST (? IN NATURAL LANGUAGE MODE)), 0) " +
+			"FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL)"
+		score = score + " + 0.5 * " + commentScore
+		args = append(args, q.Text)
+		where = "(" + where + " OR EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id " +
+			"AND comments.deleted_at IS NULL AND MATCH(comments.content) AGAINST (? IN NATURAL LANGUAGE MODE)))"
+		whereArgs = append(whereArgs, q.Text)
+	}
+
+	base := e.db.Model(&models.Post{}).
+		Where("posts.status = ?", models.PostStatusPublished).
+		Where(where, whereArgs...)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	err := base.Session(&gorm.Session{}).
+		Select("posts.id AS id, "+score+" AS score", args...).
+		Order("score DESC, posts.id DESC").
+		Offset(q.Offset).
+		Limit(q.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]Hit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, Hit{PostID: row.ID, Score: row.Score})
+	}
+	return hits, total, nil
+}
+
+func (e *mysqlEngine) IndexPost(post *models.Post)          {}
+func (e *mysqlEngine) RemovePost(postID uint)               {}
+func (e *mysqlEngine) IndexComment(comment *models.Comment) {}
+func (e *mysqlEngine) RemoveComment(commentID uint)         {}
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"gorm.io/gorm"
+)
+
+// Query 描述一次搜索请求，Offset/Limit 作用于按相关度排序后的结果。
+type Query struct {
+	Text            string
+	IncludeComments bool
+	Offset          int
+	Limit           int
+}
+
+// Hit 是一条命中的文章及其相关度得分。
+type Hit struct {
+	PostID uint
+	Score  float64
+}
+
+// Engine 是文章全文搜索的抽象。MySQL 使用 FULLTEXT 索引，其他数据库使用内存倒排索引；
+// 索引维护方法对 MySQL 实现是空操作。
+type Engine interface {
+	Search(q Query) ([]Hit, int64, error)
+	IndexPost(post *models.Post)
+	RemovePost(postID uint)
+	IndexComment(comment *models.Comment)
+	RemoveComment(commentID uint)
+}
+
+// NewEngine 根据数据库方言选择搜索实现：MySQL 直接查询迁移创建的 FULLTEXT 索引，其他数据库从现有数据重建内存索引。
+func NewEngine(db *gorm.DB) (Engine, error) {
+	if db.Dialector.Name() == "mysql" {
+		return &mysqlEngine{db: db}, nil
+	}
+
+	engine := newMemoryEngine(db)
+	if err := engine.rebuild(); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// Tokenize 将文本切分为小写词条：字母数字按单词切分，汉字等表意文字逐字切分。
+func Tokenize(text string) []string {
+	var tokens []string
+	var b strings.Builder
+	flush := func() {
+		if b.Len() > 0 {
+			tokens = append(tokens, b.String())
+			b.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Highlight 截取文本中第一个命中词附近最多 maxRunes 个字符的片段，
+// 对内容做 HTML 转义后用 <mark> 标记所有命中词。
+func Highlight(text string, terms []string, maxRunes int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	start := 0
+	if pos := firstMatch(lower, terms); pos >= 0 {
+		start = pos - maxRunes/4
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + maxRunes
+	if end > len(runes) {
+		end = len(runes)
+		if start = end - maxRunes; start < 0 {
+			start = 0
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		if n := matchAt(lower, i, end, terms); n > 0 {
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(string(runes[i : i+n])))
+			b.WriteString("</mark>")
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+		i++
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func firstMatch(lower []rune, terms []string) int {
+	for i := range lower {
+		if matchAt(lower, i, len(lower), terms) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchAt 返回 lower[i:] 开头匹配的最长词条长度（按 rune 计），未匹配返回 0。
+func matchAt(lower []rune, i, end int, terms []string) int {
+	best := 0
+	for _, term := range terms {
+		t := []rune(term)
+		if len(t) <= best || i+len(t) > end {
+			continue
+		}
+		if string(lower[i:i+len(t)]) == term {
+			best = len(t)
+		}
+	}
+	return best
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/mailer"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// AccountService 负责邮箱验证和找回密码。令牌由 JWT_SECRET 签名并带有过期时间，
+// 数据库中记录令牌摘要以保证只能使用一次，签发新令牌时同一用途的旧令牌作废。
+type AccountService struct {
+	cfg      *config.Config
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	tokens   repository.UserTokenRepository
+	mailer   mailer.Mailer
+	guard    LoginGuard
+}
+
+func NewAccountService(cfg *config.Config, users repository.UserRepository, sessions repository.SessionRepository, tokens repository.UserTokenRepository, mailer mailer.Mailer, guard LoginGuard) *AccountService {
+	return &AccountService{cfg: cfg, users: users, sessions: sessions, tokens: tokens, mailer: mailer, guard: guard}
+}
+
+// SendVerification 向用户邮箱发送验证链接。
+func (s *AccountService) SendVerification(ctx context.Context, user *models.User) error {
+	if user.EmailVerified() {
+		return apperror.Conflict("Email already verified")
+	}
+
+	token, err := s.issue(ctx, user.ID, models.TokenPurposeVerifyEmail, s.cfg.EmailVerifyTTL)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\n"+
+			"The link expires in %s. If you did not create an account, you can ignore this email.\n",
+			user.Username, s.link("/verify-email", token), formatTTL(s.cfg.EmailVerifyTTL)),
+	})
+}
+
+// VerifyEmail 使用验证令牌确认邮箱。
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	user, err := s.consume(ctx, models.TokenPurposeVerifyEmail, token)
+	if err != nil {
+		return nil, err
+	}
+	if user.EmailVerified() {
+		return user, nil
+	}
+
+	now := time.Now()
+	if err := s.users.MarkEmailVerified(ctx, user.ID, now); err != nil {
+		return nil, err
+	}
+	user.EmailVerifiedAt = &now
+	return user, nil
+}
+
+// ForgotPassword 向邮箱发送重置密码链接。邮箱未注册时同样返回成功，避免泄露账号是否存在。
+func (s *AccountService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			slog.InfoContext(ctx, "password reset requested for unknown email")
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issue(ctx, user.ID, models.TokenPurposeResetPassword, s.cfg.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+	// 发送失败只记录日志，否则错误响应会暴露该邮箱已注册
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\n"+
+			"The link expires in %s and can be used once. If you did not request a reset, you can ignore this email.\n",
+			user.Username, s.link("/reset-password", token), formatTTL(s.cfg.PasswordResetTTL)),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "send password reset email failed", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// ResetPassword 使用重置令牌设置新密码，并吊销该用户的全部会话。
+// 能收到重置邮件说明用户拥有该邮箱，因此同时将邮箱标记为已验证。
+func (s *AccountService) ResetPassword(ctx context.Context, token, password string) error {
+	user, err := s.consume(ctx, models.TokenPurposeResetPassword, token)
+	if err != nil {
+		return err
+	}
+
+	if err := user.HashPassword(password); err != nil {
+		return err
+	}
+	if err := s.users.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+		return err
+	}
+	if err := s.sessions.RevokeUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.tokens.Invalidate(ctx, user.ID, models.TokenPurposeResetPassword); err != nil {
+		return err
+	}
+	if !user.EmailVerified() {
+		if err := s.users.MarkEmailVerified(ctx, user.ID, time.Now()); err != nil {
+			return err
+		}
+	}
+	if err := s.guard.Succeeded(ctx, user.Email); err != nil {
+		slog.WarnContext(ctx, "failed to reset login failures", "error", err)
+	}
+	return nil
+}
+
+// issue 签发新令牌并记录摘要，同一用途下尚未使用的旧令牌随之作废。
+func (s *AccountService) issue(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error) {
+	if err := s.tokens.Invalidate(ctx, userID, purpose); err != nil {
+		return "", err
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	token, err := utils.SignToken(s.cfg.JWTSecret, purpose, userID, expiresAt)
+	if err != nil {
+		return "", err
+	}
+	err = s.tokens.Create(ctx, &models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consume 校验签名后把令牌标记为已使用，返回令牌所属的用户。
+func (s *AccountService) consume(ctx context.Context, purpose, token string) (*models.User, error) {
+	invalid := apperror.BadRequest("Invalid or expired token")
+
+	now := time.Now()
+	userID, err := utils.ParseSignedToken(s.cfg.JWTSecret, purpose, token, now)
+	if err != nil {
+		return nil, invalid
+	}
+	record, err := s.tokens.Consume(ctx, utils.HashToken(token), purpose, now)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	if record.UserID != userID {
+		return nil, invalid
+	}
+
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AccountService) link(path, token string) string {
+	return s.cfg.PublicURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// formatTTL 把有效期格式化为邮件中易读的形式，例如 "48 hours"。
+func formatTTL(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hour")
+	case d >= time.Minute && d%time.Minute == 0:
+		return plural(int(d/time.Minute), "minute")
+	default:
+		return d.String()
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
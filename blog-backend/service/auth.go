@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/config"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type AuthService struct {
+	cfg      *config.Config
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	events   Events
+	guard    LoginGuard
+	accounts *AccountService
+}
+
+// LoginGuard 按账号限制登录频率并在连续失败后锁定，由 ratelimit.LoginGuard 实现。
+type LoginGuard interface {
+	Allow(ctx context.Context, email string) (time.Duration, error)
+	Failed(ctx context.Context, email string) (time.Duration, error)
+	Succeeded(ctx context.Context, email string) error
+}
+
+func NewAuthService(cfg *config.Config, users repository.UserRepository, sessions repository.SessionRepository, events Events, guard LoginGuard, accounts *AccountService) *AuthService {
+	return &AuthService{cfg: cfg, users: users, sessions: sessions, events: events, guard: guard, accounts: accounts}
+}
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest) (*models.User, *TokenPair, error) {
+	if strings.HasPrefix(req.Username, models.DeletedUsernamePrefix) {
+		return nil, nil, apperror.Invalid("username", "reserved", "must not start with "+models.DeletedUsernamePrefix)
+	}
+	exists, err := s.users.ExistsByEmailOrUsername(ctx, req.Email, req.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exists {
+		return nil, nil, apperror.Conflict("User already exists")
+	}
+
+	user := &models.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Role:     models.RoleAuthor,
+		IsActive: true,
+	}
+	// ADMIN_EMAIL 指定的邮箱注册时直接成为管理员，用于初始化第一个管理员账号
+	if s.cfg.AdminEmail != "" && req.Email == s.cfg.AdminEmail {
+		user.Role = models.RoleAdmin
+	}
+
+	if err := user.HashPassword(req.Password); err != nil {
+		return nil, nil, err
+	}
+	if err := s.users.Create(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	s.events.UserRegistered()
+
+	// 验证邮件发送失败不影响注册，用户可以稍后重新发送
+	if err := s.accounts.SendVerification(ctx, user); err != nil {
+		slog.WarnContext(ctx, "failed to send verification email", "user_id", user.ID, "error", err)
+	}
+
+	tokens, err := s.issueTokens(ctx, user, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// Login 校验邮箱和密码。账号被锁定或登录过于频繁时直接拒绝，不再进行代价较高的密码校验。
+func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.User, *TokenPair, error) {
+	wait, err := s.guard.Allow(ctx, req.Email)
+	if err != nil {
+		// 限流存储故障时放行，避免所有用户无法登录
+		slog.WarnContext(ctx, "login guard unavailable", "error", err)
+	} else if wait > 0 {
+		s.events.LoginAttempted(false)
+		return nil, nil, apperror.TooManyRequests("Too many login attempts, please try again later", wait)
+	}
+
+	user, err := s.users.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, s.loginFailed(ctx, req.Email)
+		}
+		return nil, nil, err
+	}
+
+	if err := user.CheckPassword(req.Password); err != nil {
+		return nil, nil, s.loginFailed(ctx, req.Email)
+	}
+	// 密码正确后再提示账号已停用，避免泄露账号状态
+	if !user.IsActive {
+		s.events.LoginAttempted(false)
+		return nil, nil, apperror.Forbidden("Account is deactivated")
+	}
+
+	tokens, err := s.issueTokens(ctx, user, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.guard.Succeeded(ctx, req.Email); err != nil {
+		slog.WarnContext(ctx, "failed to reset login failures", "error", err)
+	}
+	s.events.LoginAttempted(true)
+	return user, tokens, nil
+}
+
+// loginFailed 记录一次失败的登录。不存在的邮箱同样计数，避免通过锁定行为探测账号是否存在。
+func (s *AuthService) loginFailed(ctx context.Context, email string) error {
+	s.events.LoginAttempted(false)
+	if _, err := s.guard.Failed(ctx, email); err != nil {
+		slog.WarnContext(ctx, "failed to record login failure", "error", err)
+	}
+	return apperror.Unauthorized("Invalid credentials")
+}
+
+// Refresh 用刷新令牌换取新的令牌对（轮换）。
+// 已轮换过的刷新令牌再次使用视为泄露，整个会话 family 会被吊销。
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*models.User, *TokenPair, error) {
+	invalid := apperror.Unauthorized("Invalid refresh token")
+	reused := apperror.Unauthorized("Refresh token reuse detected")
+
+	session, err := s.sessions.FindByRefreshTokenHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, invalid
+		}
+		return nil, nil, err
+	}
+
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, nil, invalid
+	}
+	if session.RotatedAt != nil {
+		if err := s.sessions.RevokeFamily(ctx, session.FamilyID); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, reused
+	}
+
+	user, err := s.users.FindByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, invalid
+		}
+		return nil, nil, err
+	}
+	if !user.IsActive {
+		return nil, nil, invalid
+	}
+
+	next, tokens, err := s.newSession(user, session.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.sessions.Rotate(ctx, session.ID, next); err != nil {
+		if errors.Is(err, repository.ErrAlreadyRotated) {
+			if err := s.sessions.RevokeFamily(ctx, session.FamilyID); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, reused
+		}
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// Logout 吊销访问令牌所属的整个会话 family。
+func (s *AuthService) Logout(ctx context.Context, tokenID string) error {
+	session, err := s.sessions.FindByAccessTokenID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return apperror.Unauthorized("Unauthorized")
+		}
+		return err
+	}
+	return s.sessions.RevokeFamily(ctx, session.FamilyID)
+}
+
+// Authenticate 校验 Authorization 头中的访问令牌，令牌必须对应一个未被轮换或吊销的会话。
+func (s *AuthService) Authenticate(ctx context.Context, authHeader string) (*models.User, *utils.Claims, error) {
+	if authHeader == "" {
+		return nil, nil, apperror.Unauthorized("Invalid or missing token")
+	}
+	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
+
+	claims, err := utils.ValidateToken(tokenString, s.cfg)
+	if err != nil {
+		return nil, nil, apperror.Unauthorized("Invalid or missing token")
+	}
+
+	session, err := s.sessions.FindByAccessTokenID(ctx, claims.ID)
+	if err != nil || session.UserID != claims.UserID || !session.IsActive() {
+		return nil, nil, apperror.Unauthorized("Invalid or missing token")
+	}
+
+	user, err := s.users.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, nil, apperror.NotFound("User not found")
+	}
+	if !user.IsActive {
+		return nil, nil, apperror.Unauthorized("Invalid or missing token")
+	}
+	return user, claims, nil
+}
+
+// issueTokens 创建一条新的会话记录并签发令牌对。familyID 为空时开启新的会话 family。
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, familyID string) (*TokenPair, error) {
+	session, tokens, err := s.newSession(user, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (s *AuthService) newSession(user *models.User, familyID string) (*models.Session, *TokenPair, error) {
+	if familyID == "" {
+		id, err := utils.GenerateRandomToken(16)
+		if err != nil {
+			return nil, nil, err
+		}
+		familyID = id
+	}
+
+	tokenID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, nil, err
+	}
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, nil, err
+	}
+	accessToken, err := utils.GenerateToken(user, tokenID, s.cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session := &models.Session{
+		UserID:           user.ID,
+		FamilyID:         familyID,
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		AccessTokenID:    tokenID,
+		ExpiresAt:        time.Now().Add(s.cfg.RefreshTokenTTL),
+	}
+	return session, &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.cfg.AccessTokenTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// BookmarkService 处理用户收藏的文章。
+type BookmarkService struct {
+	bookmarks repository.BookmarkRepository
+	posts     repository.PostRepository
+}
+
+func NewBookmarkService(bookmarks repository.BookmarkRepository, posts repository.PostRepository) *BookmarkService {
+	return &BookmarkService{bookmarks: bookmarks, posts: posts}
+}
+
+// Add 收藏已发布的文章。
+func (s *BookmarkService) Add(ctx context.Context, actor *models.User, postID uint) (*models.Bookmark, error) {
+	post, err := publishedPost(ctx, s.posts, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark := &models.Bookmark{UserID: actor.ID, PostID: post.ID}
+	err = s.bookmarks.Create(ctx, bookmark)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return nil, apperror.Conflict("Post already bookmarked")
+	}
+	if err != nil {
+		return nil, err
+	}
+	bookmark.Post = *post
+	return bookmark, nil
+}
+
+// Remove 取消收藏。文章被删除或下线后仍然可以取消。
+func (s *BookmarkService) Remove(ctx context.Context, actor *models.User, postID uint) error {
+	err := s.bookmarks.Delete(ctx, actor.ID, postID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound("Bookmark not found")
+	}
+	return err
+}
+
+// List 按收藏时间返回用户收藏的文章，已删除或不再公开的文章不返回。
+func (s *BookmarkService) List(ctx context.Context, actor *models.User, params *utils.PageParams) (*utils.PageResult[models.Bookmark], error) {
+	return s.bookmarks.List(ctx, actor.ID, params)
+}
+
+// publishedPost 查找已发布的文章，草稿和归档文章视为不存在。
+func publishedPost(ctx context.Context, posts repository.PostRepository, id uint) (*models.Post, error) {
+	post, err := posts.FindByID(ctx, id, false)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, apperror.NotFound("Post not found")
+	}
+	return post, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+func TestBookmarkService(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	first := newPost(t, store, alice, "First", models.PostStatusPublished)
+	second := newPost(t, store, alice, "Second", models.PostStatusPublished)
+	draft := newPost(t, store, alice, "Draft", models.PostStatusDraft)
+	svc := NewBookmarkService(store.Bookmarks(), store.Posts())
+	params := &utils.PageParams{Page: 1, Limit: 10}
+
+	_, err := svc.Add(ctx, bob, draft.ID)
+	expectError(t, err, apperror.ErrNotFound)
+	_, err = svc.Add(ctx, bob, 999)
+	expectError(t, err, apperror.ErrNotFound)
+
+	bookmark, err := svc.Add(ctx, bob, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bookmark.Post.Title != "First" {
+		t.Errorf("bookmark post = %q", bookmark.Post.Title)
+	}
+	_, err = svc.Add(ctx, bob, first.ID)
+	expectError(t, err, apperror.ErrConflict)
+	if _, err := svc.Add(ctx, bob, second.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// 下线的文章不出现在收藏列表中，但仍然可以取消收藏
+	if err := store.Posts().UpdateStatus(ctx, second.ID, models.PostStatusPublished, models.PostStatusArchived, nil); err != nil {
+		t.Fatal(err)
+	}
+	page, err := svc.List(ctx, bob, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bookmarkTitles(page.Items); !slices.Equal(got, []string{"First"}) {
+		t.Errorf("bookmarks = %v, want [First]", got)
+	}
+	if err := svc.Remove(ctx, bob, second.ID); err != nil {
+		t.Errorf("Remove() archived post error = %v", err)
+	}
+	expectError(t, svc.Remove(ctx, bob, second.ID), apperror.ErrNotFound)
+
+	others, err := svc.List(ctx, alice, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(others.Items) != 0 {
+		t.Errorf("alice bookmarks = %v, want none", bookmarkTitles(others.Items))
+	}
+}
+
+func bookmarkTitles(bookmarks []models.Bookmark) []string {
+	titles := make([]string, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		titles[i] = bookmark.Post.Title
+	}
+	return titles
+}
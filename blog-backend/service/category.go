@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type CategoryService struct {
+	categories repository.CategoryRepository
+}
+
+func NewCategoryService(categories repository.CategoryRepository) *CategoryService {
+	return &CategoryService{categories: categories}
+}
+
+func (s *CategoryService) List(ctx context.Context) ([]models.Category, error) {
+	return s.categories.List(ctx)
+}
+
+func (s *CategoryService) Create(ctx context.Context, req *models.CreateCategoryRequest) (*models.Category, error) {
+	category := &models.Category{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+	}
+	if category.Slug == "" {
+		category.Slug = utils.Slugify(req.Name)
+	}
+	if category.Slug == "" {
+		return nil, apperror.BadRequest("Invalid category slug")
+	}
+
+	if err := s.checkUnique(ctx, category); err != nil {
+		return nil, err
+	}
+	if err := s.categories.Create(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *CategoryService) Update(ctx context.Context, id uint, req *models.UpdateCategoryRequest) (*models.Category, error) {
+	category, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		category.Name = req.Name
+	}
+	if req.Slug != "" {
+		category.Slug = utils.Slugify(req.Slug)
+	}
+	if req.Description != "" {
+		category.Description = req.Description
+	}
+
+	if err := s.checkUnique(ctx, category); err != nil {
+		return nil, err
+	}
+	if err := s.categories.Update(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// Delete 删除分类，原分类下的文章变为未分类。
+func (s *CategoryService) Delete(ctx context.Context, id uint) error {
+	category, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.categories.Delete(ctx, category.ID)
+}
+
+func (s *CategoryService) find(ctx context.Context, id uint) (*models.Category, error) {
+	category, err := s.categories.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Category not found")
+	}
+	return category, err
+}
+
+func (s *CategoryService) checkUnique(ctx context.Context, category *models.Category) error {
+	exists, err := s.categories.ExistsByNameOrSlug(ctx, category.Name, category.Slug, category.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return apperror.Conflict("Category already exists")
+	}
+	return nil
+}
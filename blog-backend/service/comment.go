@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/policy"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/search"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+type CommentService struct {
+	comments repository.CommentRepository
+	posts    repository.PostRepository
+	index    search.Engine
+	maxDepth int
+	events   Events
+}
+
+func NewCommentService(comments repository.CommentRepository, posts repository.PostRepository, index search.Engine, maxDepth int, events Events) *CommentService {
+	return &CommentService{comments: comments, posts: posts, index: index, maxDepth: maxDepth, events: events}
+}
+
+// Create 在已发布的文章下发表评论，ParentID 不为空时作为回复，嵌套层数不超过 maxDepth。
+func (s *CommentService) Create(ctx context.Context, actor *models.User, postID uint, req *models.CreateCommentRequest) (*models.Comment, error) {
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, apperror.BadRequest("Comments are only allowed on published posts")
+	}
+
+	comment := &models.Comment{
+		Content: req.Content,
+		UserID:  actor.ID,
+		PostID:  post.ID,
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.comments.FindByID(ctx, post.ID, *req.ParentID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("Parent comment not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if parent.Depth+1 > s.maxDepth {
+			return nil, apperror.BadRequest("Maximum reply depth exceeded")
+		}
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	if err := s.comments.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+	s.index.IndexComment(comment)
+	s.events.CommentCreated()
+	return comment, nil
+}
+
+// List 按页返回文章下未删除的评论。
+func (s *CommentService) List(ctx context.Context, viewer *models.User, postID uint, params *utils.PageParams) (*utils.PageResult[models.Comment], error) {
+	if _, err := s.visiblePost(ctx, viewer, postID); err != nil {
+		return nil, err
+	}
+	return s.comments.List(ctx, postID, params)
+}
+
+// Thread 返回文章评论的树形结构，已删除但仍有回复的评论以占位节点保留。
+func (s *CommentService) Thread(ctx context.Context, viewer *models.User, postID uint) ([]*models.CommentNode, error) {
+	if _, err := s.visiblePost(ctx, viewer, postID); err != nil {
+		return nil, err
+	}
+	comments, err := s.comments.ListThread(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return models.BuildCommentThread(comments), nil
+}
+
+func (s *CommentService) Update(ctx context.Context, actor *models.User, postID, id uint, req *models.UpdateCommentRequest) (*models.Comment, error) {
+	_, comment, err := s.findPostComment(ctx, postID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanUpdateComment(actor, comment) {
+		return nil, apperror.Forbidden("You can only update your own comments")
+	}
+
+	comment.Content = req.Content
+	if err := s.comments.Update(ctx, comment); err != nil {
+		return nil, err
+	}
+	s.index.IndexComment(comment)
+	return comment, nil
+}
+
+// Delete 软删除评论，评论作者、文章作者以及版主/管理员可以操作。
+func (s *CommentService) Delete(ctx context.Context, actor *models.User, postID, id uint) error {
+	post, comment, err := s.findPostComment(ctx, postID, id)
+	if err != nil {
+		return err
+	}
+	if !policy.CanDeleteComment(actor, comment, post) {
+		return apperror.Forbidden("You can only delete your own comments or comments on your posts")
+	}
+
+	if err := s.comments.Delete(ctx, comment.ID); err != nil {
+		return err
+	}
+	s.index.RemoveComment(comment.ID)
+	return nil
+}
+
+// Restore 恢复已软删除的评论，仅由具备 PermDeleteAnyComment 的版主/管理员调用。所属文章须未被删除。
+func (s *CommentService) Restore(ctx context.Context, postID, id uint) (*models.Comment, error) {
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.comments.Restore(ctx, post.ID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("Deleted comment not found")
+		}
+		return nil, err
+	}
+
+	comment, err := s.comments.FindByID(ctx, post.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	s.index.IndexComment(comment)
+	return comment, nil
+}
+
+func (s *CommentService) findPost(ctx context.Context, postID uint) (*models.Post, error) {
+	post, err := s.posts.FindByID(ctx, postID, false)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	return post, err
+}
+
+func (s *CommentService) visiblePost(ctx context.Context, viewer *models.User, postID uint) (*models.Post, error) {
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanViewPost(viewer, post) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	return post, nil
+}
+
+// findPostComment 查找文章及其下的评论，评论不属于该文章时视为不存在。
+func (s *CommentService) findPostComment(ctx context.Context, postID, id uint) (*models.Post, *models.Comment, error) {
+	post, err := s.findPost(ctx, postID)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := s.comments.FindByID(ctx, post.ID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, apperror.NotFound("Comment not found")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return post, comment, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+)
+
+func TestCommentServiceOwnership(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	carol := newUser(t, store, "carol")
+	moderator := newUser(t, store, "mod")
+	moderator.Role = models.RoleModerator
+	post := newPost(t, store, alice, "Hello", models.PostStatusPublished)
+	draft := newPost(t, store, alice, "Draft", models.PostStatusDraft)
+	svc := NewCommentService(store.Comments(), store.Posts(), nopIndex{}, 2, nopEvents{})
+
+	_, err := svc.Create(ctx, bob, draft.ID, &models.CreateCommentRequest{Content: "hi"})
+	expectError(t, err, apperror.ErrInvalidInput)
+
+	first, err := svc.Create(ctx, bob, post.ID, &models.CreateCommentRequest{Content: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := svc.Create(ctx, bob, post.ID, &models.CreateCommentRequest{Content: "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只有评论作者可以修改
+	_, err = svc.Update(ctx, alice, post.ID, first.ID, &models.UpdateCommentRequest{Content: "edited"})
+	expectError(t, err, apperror.ErrForbidden)
+	if _, err := svc.Update(ctx, bob, post.ID, first.ID, &models.UpdateCommentRequest{Content: "edited"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 评论作者、文章作者和版主可以删除，其他用户不行
+	expectError(t, svc.Delete(ctx, carol, post.ID, first.ID), apperror.ErrForbidden)
+	if err := svc.Delete(ctx, alice, post.ID, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Delete(ctx, moderator, post.ID, second.ID); err != nil {
+		t.Fatal(err)
+	}
+	expectError(t, svc.Delete(ctx, bob, post.ID, second.ID), apperror.ErrNotFound)
+}
+
+func TestCommentServiceReplyDepth(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	post := newPost(t, store, alice, "Hello", models.PostStatusPublished)
+	svc := NewCommentService(store.Comments(), store.Posts(), nopIndex{}, 1, nopEvents{})
+
+	root, err := svc.Create(ctx, alice, post.ID, &models.CreateCommentRequest{Content: "root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := svc.Create(ctx, alice, post.ID, &models.CreateCommentRequest{Content: "reply", ParentID: &root.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Depth != 1 {
+		t.Errorf("depth = %d, want 1", reply.Depth)
+	}
+	_, err = svc.Create(ctx, alice, post.ID, &models.CreateCommentRequest{Content: "too deep", ParentID: &reply.ID})
+	expectError(t, err, apperror.ErrInvalidInput)
+	missing := uint(999)
+	_, err = svc.Create(ctx, alice, post.ID, &models.CreateCommentRequest{Content: "orphan", ParentID: &missing})
+	expectError(t, err, apperror.ErrNotFound)
+}
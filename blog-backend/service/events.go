@@ -0,0 +1,17 @@
+package service
+
+// Events 接收业务事件，用于统计指标，由 metrics.Metrics 实现。
+type Events interface {
+	UserRegistered()
+	LoginAttempted(success bool)
+	PostCreated()
+	CommentCreated()
+}
+
+// NopEvents 忽略所有事件，用于不需要统计的场景（例如测试）。
+type NopEvents struct{}
+
+func (NopEvents) UserRegistered()     {}
+func (NopEvents) LoginAttempted(bool) {}
+func (NopEvents) PostCreated()        {}
+func (NopEvents) CommentCreated()     {}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// FeedService 返回用户关注的作者发布的文章。动态在读取时按关注列表查询，
+// 关注列表缓存在 FolloweeCache 中，翻页时不需要重复查询关注关系。
+type FeedService struct {
+	follows   repository.FollowRepository
+	posts     repository.PostRepository
+	reactions repository.ReactionRepository
+	cache     FolloweeCache
+}
+
+func NewFeedService(follows repository.FollowRepository, posts repository.PostRepository, reactions repository.ReactionRepository, cache FolloweeCache) *FeedService {
+	return &FeedService{follows: follows, posts: posts, reactions: reactions, cache: cache}
+}
+
+// Feed 按创建时间倒序返回关注的作者已发布的文章，排序和游标与文章列表一致。
+func (s *FeedService) Feed(ctx context.Context, actor *models.User, params *utils.PageParams) (*utils.PageResult[models.Post], error) {
+	ids, err := s.followees(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 没有关注任何人时 UserIDs 为空，会被当作不过滤，因此直接返回空页
+	if len(ids) == 0 {
+		return &utils.PageResult[models.Post]{Items: []models.Post{}, Page: params.Page, Limit: params.Limit}, nil
+	}
+
+	result, err := s.posts.List(ctx, repository.PostFilter{UserIDs: ids, Status: models.PostStatusPublished}, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachReactions(ctx, s.reactions, actor, result.Items); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *FeedService) followees(ctx context.Context, userID uint) ([]uint, error) {
+	if ids, ok := s.cache.Get(userID); ok {
+		return ids, nil
+	}
+	ids, err := s.follows.FolloweeIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(userID, ids)
+	return ids, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// FolloweeCache 缓存用户关注的作者 ID，由 feed.Cache 实现。
+type FolloweeCache interface {
+	Get(userID uint) ([]uint, bool)
+	Set(userID uint, ids []uint)
+	Invalidate(userID uint)
+}
+
+// FollowService 处理用户之间的关注关系。停用的账号不能被关注，也不出现在粉丝和关注列表中或计入计数。
+type FollowService struct {
+	follows repository.FollowRepository
+	users   repository.UserRepository
+	cache   FolloweeCache
+}
+
+func NewFollowService(follows repository.FollowRepository, users repository.UserRepository, cache FolloweeCache) *FollowService {
+	return &FollowService{follows: follows, users: users, cache: cache}
+}
+
+// Follow 关注用户，返回对方更新后的公开资料。
+func (s *FollowService) Follow(ctx context.Context, actor *models.User, username string) (*models.PublicProfile, error) {
+	target, err := s.activeUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == actor.ID {
+		return nil, apperror.BadRequest("You cannot follow yourself")
+	}
+	if actor.FollowingCount >= models.MaxFollowing {
+		return nil, apperror.BadRequest("Following limit reached")
+	}
+
+	err = s.follows.Create(ctx, &models.Follow{FollowerID: actor.ID, FolloweeID: target.ID})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return nil, apperror.Conflict("Already following this user")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Invalidate(actor.ID)
+	return s.profile(ctx, target)
+}
+
+// Unfollow 取消关注，返回对方更新后的公开资料。已停用的用户同样可以取消关注。
+func (s *FollowService) Unfollow(ctx context.Context, actor *models.User, username string) (*models.PublicProfile, error) {
+	target, err := s.users.FindByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("User not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.follows.Delete(ctx, actor.ID, target.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Not following this user")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Invalidate(actor.ID)
+	return s.profile(ctx, target)
+}
+
+// Followers 按关注时间倒序返回用户的粉丝。
+func (s *FollowService) Followers(ctx context.Context, username string, params *utils.PageParams) (*utils.PageResult[models.FollowProfile], error) {
+	user, err := s.activeUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.follows.Followers(ctx, user.ID, params)
+	if err != nil {
+		return nil, err
+	}
+	return followProfiles(page, func(f *models.Follow) *models.User { return &f.Follower }), nil
+}
+
+// Following 按关注时间倒序返回用户关注的人。
+func (s *FollowService) Following(ctx context.Context, username string, params *utils.PageParams) (*utils.PageResult[models.FollowProfile], error) {
+	user, err := s.activeUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.follows.Following(ctx, user.ID, params)
+	if err != nil {
+		return nil, err
+	}
+	return followProfiles(page, func(f *models.Follow) *models.User { return &f.Followee }), nil
+}
+
+// activeUser 按用户名查找用户，停用的账号视为不存在。
+func (s *FollowService) activeUser(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.users.FindByUsername(ctx, username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("User not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, apperror.NotFound("User not found")
+	}
+	return user, nil
+}
+
+// profile 重新读取用户以返回最新的粉丝数。
+func (s *FollowService) profile(ctx context.Context, user *models.User) (*models.PublicProfile, error) {
+	fresh, err := s.users.FindByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	profile := fresh.Public()
+	return &profile, nil
+}
+
+// followProfiles 把关注关系的分页结果转换为对方的公开资料，user 返回关系中对方的用户。
+func followProfiles(page *utils.PageResult[models.Follow], user func(*models.Follow) *models.User) *utils.PageResult[models.FollowProfile] {
+	items := make([]models.FollowProfile, len(page.Items))
+	for i := range page.Items {
+		items[i] = models.FollowProfile{PublicProfile: user(&page.Items[i]).Public(), FollowedAt: page.Items[i].CreatedAt}
+	}
+	return &utils.PageResult[models.FollowProfile]{
+		Items:      items,
+		Page:       page.Page,
+		Limit:      page.Limit,
+		Total:      page.Total,
+		HasNext:    page.HasNext,
+		NextCursor: page.NextCursor,
+	}
+}
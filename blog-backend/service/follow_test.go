@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/feed"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+func TestFollowServiceCounts(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	carol := newUser(t, store, "carol")
+	svc := NewFollowService(store.Follows(), store.Users(), feed.NewCache(time.Minute, 10))
+
+	_, err := svc.Follow(ctx, alice, "alice")
+	expectError(t, err, apperror.ErrInvalidInput)
+	_, err = svc.Follow(ctx, alice, "nobody")
+	expectError(t, err, apperror.ErrNotFound)
+
+	profile, err := svc.Follow(ctx, bob, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.FollowerCount != 1 {
+		t.Errorf("follower_count = %d, want 1", profile.FollowerCount)
+	}
+	_, err = svc.Follow(ctx, bob, "alice")
+	expectError(t, err, apperror.ErrConflict)
+	if _, err := svc.Follow(ctx, carol, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 停用的粉丝既不出现在列表中，也不计入粉丝数，重新启用后恢复
+	if err := store.Users().SetActive(ctx, carol.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	followers, err := svc.Followers(ctx, "alice", &utils.PageParams{Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(followers.Items) != 1 || followers.Items[0].Username != "bob" {
+		t.Errorf("followers = %+v, want only bob", followers.Items)
+	}
+	if got := followerCount(t, store, alice.ID); got != 1 {
+		t.Errorf("follower_count after deactivation = %d, want 1", got)
+	}
+	_, err = svc.Follow(ctx, bob, "carol")
+	expectError(t, err, apperror.ErrNotFound)
+
+	if err := store.Users().SetActive(ctx, carol.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := followerCount(t, store, alice.ID); got != 2 {
+		t.Errorf("follower_count after reactivation = %d, want 2", got)
+	}
+
+	profile, err = svc.Unfollow(ctx, bob, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.FollowerCount != 1 {
+		t.Errorf("follower_count after unfollow = %d, want 1", profile.FollowerCount)
+	}
+	_, err = svc.Unfollow(ctx, bob, "alice")
+	expectError(t, err, apperror.ErrNotFound)
+}
+
+func followerCount(t *testing.T, store *memory.Store, id uint) int64 {
+	t.Helper()
+	user, err := store.Users().FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user.FollowerCount
+}
+
+func TestFeedServiceInvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	carol := newUser(t, store, "carol")
+	cache := feed.NewCache(time.Hour, 10)
+	follows := NewFollowService(store.Follows(), store.Users(), cache)
+	feeds := NewFeedService(store.Follows(), store.Posts(), store.Reactions(), cache)
+	params := &utils.PageParams{Page: 1, Limit: 10}
+
+	newPost(t, store, alice, "A1", models.PostStatusPublished)
+	newPost(t, store, alice, "Draft", models.PostStatusDraft)
+	newPost(t, store, carol, "C1", models.PostStatusPublished)
+
+	empty, err := feeds.Feed(ctx, bob, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(empty.Items) != 0 {
+		t.Errorf("feed without follows = %v", postTitles(empty.Items))
+	}
+
+	// 关注和取消关注立即反映在动态中，不等缓存过期
+	if _, err := follows.Follow(ctx, bob, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	page, err := feeds.Feed(ctx, bob, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := postTitles(page.Items); !slices.Equal(got, []string{"A1"}) {
+		t.Errorf("feed = %v, want [A1]", got)
+	}
+	if _, err := follows.Follow(ctx, bob, "carol"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := follows.Unfollow(ctx, bob, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	page, err = feeds.Feed(ctx, bob, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := postTitles(page.Items); !slices.Equal(got, []string{"C1"}) {
+		t.Errorf("feed after changes = %v, want [C1]", got)
+	}
+}
+
+func postTitles(posts []models.Post) []string {
+	titles := make([]string, len(posts))
+	for i, post := range posts {
+		titles[i] = post.Title
+	}
+	return titles
+}
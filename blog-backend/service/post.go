@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/policy"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/search"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// ViewRecorder 记录文章浏览，由 views.Tracker 实现。
+type ViewRecorder interface {
+	Record(postID uint, viewer string) bool
+}
+
+type PostService struct {
+	posts      repository.PostRepository
+	categories repository.CategoryRepository
+	index      search.Engine
+	events     Events
+	views      ViewRecorder
+	reactions  repository.ReactionRepository
+}
+
+func NewPostService(posts repository.PostRepository, categories repository.CategoryRepository, index search.Engine, events Events, views ViewRecorder, reactions repository.ReactionRepository) *PostService {
+	return &PostService{posts: posts, categories: categories, index: index, events: events, views: views, reactions: reactions}
+}
+
+// Create 以草稿状态创建文章。
+func (s *PostService) Create(ctx context.Context, actor *models.User, req *models.CreatePostRequest) (*models.Post, error) {
+	if err := s.checkCategory(ctx, req.CategoryID); err != nil {
+		return nil, err
+	}
+
+	post := &models.Post{
+		Title:      req.Title,
+		Content:    req.Content,
+		Status:     models.PostStatusDraft,
+		PublishAt:  req.PublishAt,
+		CategoryID: req.CategoryID,
+		UserID:     actor.ID,
+	}
+	if err := s.posts.Create(ctx, post, req.Tags); err != nil {
+		return nil, err
+	}
+	s.index.IndexPost(post)
+	s.events.PostCreated()
+
+	return s.posts.FindByID(ctx, post.ID, false)
+}
+
+// Get 返回文章详情，未发布的文章对无权查看的用户表现为不存在。
+func (s *PostService) Get(ctx context.Context, viewer *models.User, id uint) (*models.Post, error) {
+	post, err := s.find(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanViewPost(viewer, post) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	posts := []models.Post{*post}
+	if err := attachReactions(ctx, s.reactions, viewer, posts); err != nil {
+		return nil, err
+	}
+	return &posts[0], nil
+}
+
+// RecordView 记录一次浏览。只统计已发布文章，作者浏览自己的文章不计数；
+// 登录用户按用户去重，匿名访客按 IP 去重。
+func (s *PostService) RecordView(post *models.Post, viewer *models.User, clientIP string) {
+	if !post.IsPublished() {
+		return
+	}
+	key := "ip:" + clientIP
+	if viewer != nil {
+		if viewer.ID == post.UserID {
+			return
+		}
+		key = "user:" + strconv.FormatUint(uint64(viewer.ID), 10)
+	}
+	s.views.Record(post.ID, key)
+}
+
+// ListPublished 返回已发布文章，可按标签和分类过滤，viewer 用于标记其已留下的表态，可以为 nil。
+func (s *PostService) ListPublished(ctx context.Context, viewer *models.User, tag, category string, params *utils.PageParams) (*utils.PageResult[models.Post], error) {
+	return s.list(ctx, viewer, repository.PostFilter{
+		Status:   models.PostStatusPublished,
+		Tag:      tag,
+		Category: category,
+	}, params)
+}
+
+// ListByAuthor 返回作者自己的文章，status 为空时返回所有状态。
+func (s *PostService) ListByAuthor(ctx context.Context, actor *models.User, status string, params *utils.PageParams) (*utils.PageResult[models.Post], error) {
+	return s.list(ctx, actor, repository.PostFilter{UserID: actor.ID, Status: status}, params)
+}
+
+func (s *PostService) list(ctx context.Context, viewer *models.User, filter repository.PostFilter, params *utils.PageParams) (*utils.PageResult[models.Post], error) {
+	result, err := s.posts.List(ctx, filter, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachReactions(ctx, s.reactions, viewer, result.Items); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *PostService) Update(ctx context.Context, actor *models.User, id uint, req *models.UpdatePostRequest) (*models.Post, error) {
+	post, err := s.find(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanUpdatePost(actor, post) {
+		return nil, apperror.Forbidden("You can only update your own posts")
+	}
+
+	if req.Title != "" {
+		post.Title = req.Title
+	}
+	if req.Content != "" {
+		post.Content = req.Content
+	}
+	if req.PublishAt != nil {
+		post.PublishAt = req.PublishAt
+	}
+	if req.CategoryID != nil {
+		if err := s.checkCategory(ctx, req.CategoryID); err != nil {
+			return nil, err
+		}
+		post.CategoryID = req.CategoryID
+	}
+
+	if err := s.posts.Update(ctx, post, req.Tags); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("Post not found")
+		}
+		return nil, err
+	}
+	s.index.IndexPost(post)
+
+	return s.posts.FindByID(ctx, post.ID, false)
+}
+
+// ChangeStatus 发布或归档文章，首次发布时记录发布时间。状态已是目标状态时不做修改，
+// 不允许的状态变更（例如归档草稿）返回 400；读取后状态被并发修改（例如定时发布）时返回 409，由客户端刷新后重试。
+func (s *PostService) ChangeStatus(ctx context.Context, actor *models.User, id uint, status string) (*models.Post, error) {
+	post, err := s.find(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanUpdatePost(actor, post) {
+		return nil, apperror.Forbidden("You can only update your own posts")
+	}
+	if post.Status == status {
+		return post, nil
+	}
+	if !post.CanTransitionTo(status) {
+		return nil, apperror.BadRequest(fmt.Sprintf("Cannot change post status from %s to %s", post.Status, status))
+	}
+
+	var publishedAt *time.Time
+	if status == models.PostStatusPublished && post.PublishedAt == nil {
+		now := time.Now()
+		publishedAt = &now
+	}
+	if err := s.posts.UpdateStatus(ctx, post.ID, post.Status, status, publishedAt); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.Conflict("Post was modified concurrently, please retry")
+		}
+		return nil, err
+	}
+
+	return s.posts.FindByID(ctx, post.ID, false)
+}
+
+func (s *PostService) Delete(ctx context.Context, actor *models.User, id uint) error {
+	post, err := s.find(ctx, id, false)
+	if err != nil {
+		return err
+	}
+	if !policy.CanDeletePost(actor, post) {
+		return apperror.Forbidden("You can only delete your own posts")
+	}
+
+	if err := s.posts.Delete(ctx, post.ID); err != nil {
+		return err
+	}
+	s.index.RemovePost(post.ID)
+	return nil
+}
+
+// Restore 恢复已软删除的文章，仅由具备 PermDeleteAnyPost 的版主/管理员调用。
+func (s *PostService) Restore(ctx context.Context, id uint) (*models.Post, error) {
+	if err := s.posts.Restore(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("Deleted post not found")
+		}
+		return nil, err
+	}
+
+	post, err := s.posts.FindByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	s.index.IndexPost(post)
+	return post, nil
+}
+
+func (s *PostService) ListTags(ctx context.Context) ([]models.TagWithCount, error) {
+	return s.posts.ListTags(ctx)
+}
+
+func (s *PostService) find(ctx context.Context, id uint, withComments bool) (*models.Post, error) {
+	post, err := s.posts.FindByID(ctx, id, withComments)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	return post, err
+}
+
+func (s *PostService) checkCategory(ctx context.Context, id *uint) error {
+	if id == nil {
+		return nil
+	}
+	_, err := s.categories.FindByID(ctx, *id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.BadRequest("Category not found")
+	}
+	return err
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+)
+
+func newPostService(store *memory.Store, posts repository.PostRepository) *PostService {
+	return NewPostService(posts, store.Categories(), nopIndex{}, nopEvents{}, nopViews{}, store.Reactions())
+}
+
+func TestPostServiceOwnership(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	svc := newPostService(store, store.Posts())
+
+	post, err := svc.Create(ctx, alice, &models.CreatePostRequest{Title: "Draft", Content: "content"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Status != models.PostStatusDraft {
+		t.Errorf("status = %q, want draft", post.Status)
+	}
+
+	// 草稿只对作者可见，其他用户看到的是不存在
+	if _, err := svc.Get(ctx, alice, post.ID); err != nil {
+		t.Errorf("author Get() error = %v", err)
+	}
+	_, err = svc.Get(ctx, bob, post.ID)
+	expectError(t, err, apperror.ErrNotFound)
+	_, err = svc.Get(ctx, nil, post.ID)
+	expectError(t, err, apperror.ErrNotFound)
+
+	// 只有作者可以修改和删除
+	_, err = svc.Update(ctx, bob, post.ID, &models.UpdatePostRequest{Title: "Hijacked"})
+	expectError(t, err, apperror.ErrForbidden)
+	expectError(t, svc.Delete(ctx, bob, post.ID), apperror.ErrForbidden)
+
+	updated, err := svc.Update(ctx, alice, post.ID, &models.UpdatePostRequest{Title: "Edited"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "Edited" || updated.Content != "content" {
+		t.Errorf("updated post = %q %q", updated.Title, updated.Content)
+	}
+	if err := svc.Delete(ctx, alice, post.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.Get(ctx, alice, post.ID)
+	expectError(t, err, apperror.ErrNotFound)
+}
+
+func TestPostServiceValidatesCategory(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	svc := newPostService(store, store.Posts())
+
+	missing := uint(999)
+	_, err := svc.Create(ctx, alice, &models.CreatePostRequest{Title: "T", Content: "c", CategoryID: &missing})
+	expectError(t, err, apperror.ErrInvalidInput)
+
+	category := &models.Category{Name: "Go", Slug: "go"}
+	if err := store.Categories().Create(ctx, category); err != nil {
+		t.Fatal(err)
+	}
+	post, err := svc.Create(ctx, alice, &models.CreatePostRequest{Title: "T", Content: "c", CategoryID: &category.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.Update(ctx, alice, post.ID, &models.UpdatePostRequest{CategoryID: &missing})
+	expectError(t, err, apperror.ErrInvalidInput)
+}
+
+func TestPostServiceChangeStatus(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	post := newPost(t, store, alice, "Hello", models.PostStatusDraft)
+	svc := newPostService(store, store.Posts())
+
+	// 草稿不能直接归档，其他用户不能修改状态
+	_, err := svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusArchived)
+	expectError(t, err, apperror.ErrInvalidInput)
+	_, err = svc.ChangeStatus(ctx, bob, post.ID, models.PostStatusPublished)
+	expectError(t, err, apperror.ErrForbidden)
+
+	published, err := svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusPublished)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.Status != models.PostStatusPublished || published.PublishedAt == nil {
+		t.Fatalf("first publish = %q at %v, want published with a timestamp", published.Status, published.PublishedAt)
+	}
+	firstPublished := *published.PublishedAt
+
+	// 重复发布不做修改
+	again, err := svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusPublished)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.PublishedAt.Equal(firstPublished) {
+		t.Errorf("repeated publish moved published_at to %v", again.PublishedAt)
+	}
+
+	// 归档后重新发布保留首次发布时间
+	archived, err := svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusArchived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived.Status != models.PostStatusArchived {
+		t.Errorf("status = %q, want archived", archived.Status)
+	}
+	_, err = svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusDraft)
+	expectError(t, err, apperror.ErrInvalidInput)
+	republished, err := svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusPublished)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if republished.Status != models.PostStatusPublished || !republished.PublishedAt.Equal(firstPublished) {
+		t.Errorf("republished = %q at %v, want published at %v", republished.Status, republished.PublishedAt, firstPublished)
+	}
+}
+
+// racingPosts 在修改状态前模拟定时任务抢先发布了文章。
+type racingPosts struct {
+	*memory.PostRepository
+}
+
+func (r racingPosts) UpdateStatus(ctx context.Context, id uint, from, to string, publishedAt *time.Time) error {
+	now := time.Now()
+	if err := r.PostRepository.UpdateStatus(ctx, id, models.PostStatusDraft, models.PostStatusPublished, &now); err != nil {
+		return err
+	}
+	return r.PostRepository.UpdateStatus(ctx, id, from, to, publishedAt)
+}
+
+func TestPostServiceChangeStatusConflict(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	post := newPost(t, store, alice, "Hello", models.PostStatusDraft)
+	svc := newPostService(store, racingPosts{store.Posts()})
+
+	_, err := svc.ChangeStatus(ctx, alice, post.ID, models.PostStatusPublished)
+	expectError(t, err, apperror.ErrConflict)
+
+	current, err := store.Posts().FindByID(ctx, post.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Status != models.PostStatusPublished {
+		t.Errorf("status = %q, want the concurrent change to win", current.Status)
+	}
+
+	// 编辑内容不会覆盖并发修改的状态
+	updated, err := svc.Update(ctx, alice, post.ID, &models.UpdatePostRequest{Title: "Edited"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "Edited" || updated.Status != models.PostStatusPublished {
+		t.Errorf("updated post = %q %q", updated.Title, updated.Status)
+	}
+}
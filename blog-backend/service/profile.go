@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// ProfileService 处理当前用户对自己账号的管理，以及公开的用户主页。
+type ProfileService struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	posts    repository.PostRepository
+}
+
+func NewProfileService(users repository.UserRepository, sessions repository.SessionRepository, posts repository.PostRepository) *ProfileService {
+	return &ProfileService{users: users, sessions: sessions, posts: posts}
+}
+
+// UserPage 是公开的用户主页：资料和已发布的文章。
+type UserPage struct {
+	User  models.PublicProfile           `json:"user"`
+	Posts *utils.PageResult[models.Post] `json:"posts"`
+}
+
+func (s *ProfileService) Update(ctx context.Context, actor *models.User, req *models.UpdateProfileRequest) (*models.User, error) {
+	user := *actor
+	if req.Nickname != nil {
+		user.Nickname = *req.Nickname
+	}
+	if req.Avatar != nil {
+		if *req.Avatar != "" && !isHTTPURL(*req.Avatar) {
+			return nil, apperror.Invalid("avatar", "url", "must be an http or https URL")
+		}
+		user.Avatar = *req.Avatar
+	}
+	if req.Bio != nil {
+		user.Bio = *req.Bio
+	}
+
+	if err := s.users.UpdateProfile(ctx, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ChangePassword 校验当前密码后设置新密码，并吊销除当前会话外的所有会话。
+func (s *ProfileService) ChangePassword(ctx context.Context, actor *models.User, tokenID string, req *models.ChangePasswordRequest) error {
+	if err := actor.CheckPassword(req.CurrentPassword); err != nil {
+		return apperror.Invalid("current_password", "password", "is incorrect")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return apperror.Invalid("new_password", "nefield", "must differ from the current password")
+	}
+
+	session, err := s.sessions.FindByAccessTokenID(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	user := *actor
+	if err := user.HashPassword(req.NewPassword); err != nil {
+		return err
+	}
+	if err := s.users.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+		return err
+	}
+	return s.sessions.RevokeUserExcept(ctx, user.ID, session.FamilyID)
+}
+
+// Deactivate 停用账号并吊销所有会话。停用后无法登录，主页不可见，需要管理员重新启用。
+func (s *ProfileService) Deactivate(ctx context.Context, actor *models.User, password string) error {
+	if err := actor.CheckPassword(password); err != nil {
+		return apperror.Invalid("password", "password", "is incorrect")
+	}
+	if err := s.users.SetActive(ctx, actor.ID, false); err != nil {
+		return err
+	}
+	return s.sessions.RevokeUser(ctx, actor.ID)
+}
+
+// Delete 软删除账号并吊销所有会话，已发布的文章和评论保留。
+func (s *ProfileService) Delete(ctx context.Context, actor *models.User, password string) error {
+	if err := actor.CheckPassword(password); err != nil {
+		return apperror.Invalid("password", "password", "is incorrect")
+	}
+	if err := s.users.Delete(ctx, actor.ID); err != nil {
+		return err
+	}
+	return s.sessions.RevokeUser(ctx, actor.ID)
+}
+
+// Page 返回用户的公开主页，停用的账号视为不存在。
+func (s *ProfileService) Page(ctx context.Context, username string, params *utils.PageParams) (*UserPage, error) {
+	user, err := s.users.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("User not found")
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, apperror.NotFound("User not found")
+	}
+
+	posts, err := s.posts.List(ctx, repository.PostFilter{UserID: user.ID, Status: models.PostStatusPublished}, params)
+	if err != nil {
+		return nil, err
+	}
+	return &UserPage{User: user.Public(), Posts: posts}, nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
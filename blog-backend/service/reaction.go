@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/policy"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+// ReactionService 处理用户对文章的表态（like、love 等）。
+type ReactionService struct {
+	reactions repository.ReactionRepository
+	posts     repository.PostRepository
+}
+
+func NewReactionService(reactions repository.ReactionRepository, posts repository.PostRepository) *ReactionService {
+	return &ReactionService{reactions: reactions, posts: posts}
+}
+
+// Add 为文章添加表态，只能对已发布的文章表态，返回文章最新的表态汇总。
+func (s *ReactionService) Add(ctx context.Context, actor *models.User, postID uint, reactionType string) ([]models.ReactionSummary, error) {
+	post, err := s.visiblePost(ctx, actor, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, apperror.BadRequest("Reactions are only allowed on published posts")
+	}
+
+	err = s.reactions.Create(ctx, &models.Reaction{PostID: post.ID, UserID: actor.ID, Type: reactionType})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return nil, apperror.Conflict("You already left this reaction")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.summary(ctx, actor, post.ID)
+}
+
+// Remove 撤销当前用户的表态，返回文章最新的表态汇总。
+func (s *ReactionService) Remove(ctx context.Context, actor *models.User, postID uint, reactionType string) ([]models.ReactionSummary, error) {
+	post, err := s.visiblePost(ctx, actor, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.reactions.Delete(ctx, post.ID, actor.ID, reactionType)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Reaction not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.summary(ctx, actor, post.ID)
+}
+
+func (s *ReactionService) visiblePost(ctx context.Context, viewer *models.User, postID uint) (*models.Post, error) {
+	post, err := s.posts.FindByID(ctx, postID, false)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanViewPost(viewer, post) {
+		return nil, apperror.NotFound("Post not found")
+	}
+	return post, nil
+}
+
+func (s *ReactionService) summary(ctx context.Context, viewer *models.User, postID uint) ([]models.ReactionSummary, error) {
+	summaries, err := s.reactions.Summaries(ctx, []uint{postID}, viewerID(viewer))
+	if err != nil {
+		return nil, err
+	}
+	return summaries[postID], nil
+}
+
+// attachReactions 为文章填充表态汇总，viewer 为 nil 时所有 Reacted 均为 false。
+func attachReactions(ctx context.Context, reactions repository.ReactionRepository, viewer *models.User, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+	}
+	summaries, err := reactions.Summaries(ctx, ids, viewerID(viewer))
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		posts[i].Reactions = summaries[posts[i].ID]
+	}
+	return nil
+}
+
+func viewerID(viewer *models.User) uint {
+	if viewer == nil {
+		return 0
+	}
+	return viewer.ID
+}
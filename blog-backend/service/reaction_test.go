@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+)
+
+func TestReactionService(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	post := newPost(t, store, alice, "Hello", models.PostStatusPublished)
+	draft := newPost(t, store, alice, "Draft", models.PostStatusDraft)
+	svc := NewReactionService(store.Reactions(), store.Posts())
+
+	// 草稿对其他人不可见，作者自己也不能表态
+	_, err := svc.Add(ctx, bob, draft.ID, "like")
+	expectError(t, err, apperror.ErrNotFound)
+	_, err = svc.Add(ctx, alice, draft.ID, "like")
+	expectError(t, err, apperror.ErrInvalidInput)
+
+	if _, err := svc.Add(ctx, alice, post.ID, "like"); err != nil {
+		t.Fatal(err)
+	}
+	summaries, err := svc.Add(ctx, bob, post.ID, "like")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if like := summaries[0]; like.Type != "like" || like.Count != 2 || !like.Reacted {
+		t.Errorf("like = %+v, want 2 likes reacted by bob", like)
+	}
+	_, err = svc.Add(ctx, bob, post.ID, "like")
+	expectError(t, err, apperror.ErrConflict)
+
+	summaries, err = svc.Remove(ctx, bob, post.ID, "like")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if like := summaries[0]; like.Count != 1 || like.Reacted {
+		t.Errorf("like after remove = %+v, want 1 like not reacted by bob", like)
+	}
+	_, err = svc.Remove(ctx, bob, post.ID, "like")
+	expectError(t, err, apperror.ErrNotFound)
+}
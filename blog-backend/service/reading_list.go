@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/utils"
+)
+
+// slugAttempts 是生成的 slug 冲突时的重试次数，随机后缀冲突的概率极低。
+const slugAttempts = 3
+
+// ReadingListService 管理用户的阅读列表。列表只有所有者可以查看和修改，
+// 公开的列表任何人都可以通过 slug 访问，其中只显示已发布的文章。
+type ReadingListService struct {
+	lists repository.ReadingListRepository
+	posts repository.PostRepository
+	users repository.UserRepository
+}
+
+func NewReadingListService(lists repository.ReadingListRepository, posts repository.PostRepository, users repository.UserRepository) *ReadingListService {
+	return &ReadingListService{lists: lists, posts: posts, users: users}
+}
+
+func (s *ReadingListService) List(ctx context.Context, actor *models.User) ([]models.ReadingList, error) {
+	return s.lists.ListByUser(ctx, actor.ID)
+}
+
+// Create 创建列表，slug 由名称和随机后缀组成，之后改名不会改变分享链接。
+func (s *ReadingListService) Create(ctx context.Context, actor *models.User, req *models.CreateReadingListRequest) (*models.ReadingList, error) {
+	count, err := s.lists.CountByUser(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= models.MaxReadingListsPerUser {
+		return nil, apperror.BadRequest("Reading list limit reached")
+	}
+
+	list := &models.ReadingList{
+		UserID:      actor.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		IsPublic:    req.IsPublic,
+	}
+	for attempt := 0; ; attempt++ {
+		if list.Slug, err = listSlug(req.Name); err != nil {
+			return nil, err
+		}
+		err = s.lists.Create(ctx, list)
+		if !errors.Is(err, repository.ErrDuplicate) || attempt+1 == slugAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	list.Items = []models.ReadingListItem{}
+	return list, nil
+}
+
+// Get 返回当前用户的列表及其中的文章。
+func (s *ReadingListService) Get(ctx context.Context, actor *models.User, id uint) (*models.ReadingList, error) {
+	list, err := s.owned(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.Items, err = s.lists.Items(ctx, list.ID, actor.ID); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetPublic 按 slug 返回公开的列表，未公开或所有者已停用的列表视为不存在。
+func (s *ReadingListService) GetPublic(ctx context.Context, slug string) (*models.ReadingList, error) {
+	notFound := apperror.NotFound("Reading list not found")
+
+	list, err := s.lists.FindBySlug(ctx, slug)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !list.IsPublic {
+		return nil, notFound
+	}
+
+	owner, err := s.users.FindByID(ctx, list.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !owner.IsActive {
+		return nil, notFound
+	}
+	profile := owner.Public()
+	list.Owner = &profile
+
+	if list.Items, err = s.lists.Items(ctx, list.ID, 0); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *ReadingListService) Update(ctx context.Context, actor *models.User, id uint, req *models.UpdateReadingListRequest) (*models.ReadingList, error) {
+	list, err := s.owned(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		list.Name = *req.Name
+	}
+	if req.Description != nil {
+		list.Description = *req.Description
+	}
+	if req.IsPublic != nil {
+		list.IsPublic = *req.IsPublic
+	}
+
+	if err := s.lists.Update(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *ReadingListService) Delete(ctx context.Context, actor *models.User, id uint) error {
+	list, err := s.owned(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	return s.lists.Delete(ctx, list.ID)
+}
+
+// AddItem 把已发布的文章追加到列表末尾，返回更新后的列表。
+func (s *ReadingListService) AddItem(ctx context.Context, actor *models.User, id, postID uint) (*models.ReadingList, error) {
+	list, err := s.owned(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	post, err := publishedPost(ctx, s.posts, postID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.lists.CountItems(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= models.MaxReadingListItems {
+		return nil, apperror.BadRequest("Reading list is full")
+	}
+
+	err = s.lists.AddItem(ctx, &models.ReadingListItem{ListID: list.ID, PostID: post.ID})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return nil, apperror.Conflict("Post already in reading list")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, actor, list.ID)
+}
+
+func (s *ReadingListService) RemoveItem(ctx context.Context, actor *models.User, id, postID uint) (*models.ReadingList, error) {
+	list, err := s.owned(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.lists.RemoveItem(ctx, list.ID, postID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Post not in reading list")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, actor, list.ID)
+}
+
+// Reorder 调整列表中文章的顺序，postIDs 中的文章依次排在最前面。
+func (s *ReadingListService) Reorder(ctx context.Context, actor *models.User, id uint, postIDs []uint) (*models.ReadingList, error) {
+	list, err := s.owned(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.lists.Reorder(ctx, list.ID, postIDs)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.Invalid("post_ids", "in_list", "must only contain posts in the reading list")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, actor, list.ID)
+}
+
+// owned 查找当前用户的列表，其他用户的列表视为不存在。
+func (s *ReadingListService) owned(ctx context.Context, actor *models.User, id uint) (*models.ReadingList, error) {
+	list, err := s.lists.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("Reading list not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if list.UserID != actor.ID {
+		return nil, apperror.NotFound("Reading list not found")
+	}
+	return list, nil
+}
+
+// listSlug 由名称生成 slug 并追加随机后缀，名称无法转换时使用 "list"。
+func listSlug(name string) (string, error) {
+	base := utils.Slugify(name)
+	if base == "" {
+		base = "list"
+	}
+	if runes := []rune(base); len(runes) > 60 {
+		base = strings.TrimSuffix(string(runes[:60]), "-")
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return base + "-" + hex.EncodeToString(suffix), nil
+}
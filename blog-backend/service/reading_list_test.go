@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+)
+
+func TestReadingListService(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	bob := newUser(t, store, "bob")
+	first := newPost(t, store, bob, "First", models.PostStatusPublished)
+	second := newPost(t, store, bob, "Second", models.PostStatusPublished)
+	third := newPost(t, store, bob, "Third", models.PostStatusPublished)
+	draft := newPost(t, store, bob, "Draft", models.PostStatusDraft)
+	svc := NewReadingListService(store.ReadingLists(), store.Posts(), store.Users())
+
+	list, err := svc.Create(ctx, alice, &models.CreateReadingListRequest{Name: "Go Reading"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(list.Slug, "go-reading-") {
+		t.Errorf("slug = %q, want go-reading- prefix", list.Slug)
+	}
+
+	// 其他用户的列表视为不存在
+	_, err = svc.Get(ctx, bob, list.ID)
+	expectError(t, err, apperror.ErrNotFound)
+	_, err = svc.AddItem(ctx, bob, list.ID, first.ID)
+	expectError(t, err, apperror.ErrNotFound)
+
+	for _, post := range []*models.Post{first, second, third} {
+		if _, err := svc.AddItem(ctx, alice, list.ID, post.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = svc.AddItem(ctx, alice, list.ID, first.ID)
+	expectError(t, err, apperror.ErrConflict)
+	_, err = svc.AddItem(ctx, alice, list.ID, draft.ID)
+	expectError(t, err, apperror.ErrNotFound)
+
+	reordered, err := svc.Reorder(ctx, alice, list.ID, []uint{third.ID, first.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := itemTitles(reordered.Items); !slices.Equal(got, []string{"Third", "First", "Second"}) {
+		t.Errorf("items after reorder = %v", got)
+	}
+	_, err = svc.Reorder(ctx, alice, list.ID, []uint{draft.ID})
+	expectError(t, err, &apperror.Error{Code: apperror.CodeValidationFailed})
+
+	removed, err := svc.RemoveItem(ctx, alice, list.ID, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := itemTitles(removed.Items); !slices.Equal(got, []string{"Third", "Second"}) {
+		t.Errorf("items after remove = %v", got)
+	}
+	_, err = svc.RemoveItem(ctx, alice, list.ID, first.ID)
+	expectError(t, err, apperror.ErrNotFound)
+}
+
+func TestReadingListServiceGetPublic(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	post := newPost(t, store, alice, "Shared", models.PostStatusPublished)
+	svc := NewReadingListService(store.ReadingLists(), store.Posts(), store.Users())
+
+	list, err := svc.Create(ctx, alice, &models.CreateReadingListRequest{Name: "Shared"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AddItem(ctx, alice, list.ID, post.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.GetPublic(ctx, list.Slug)
+	expectError(t, err, apperror.ErrNotFound)
+
+	public := true
+	if _, err := svc.Update(ctx, alice, list.ID, &models.UpdateReadingListRequest{IsPublic: &public}); err != nil {
+		t.Fatal(err)
+	}
+	shared, err := svc.GetPublic(ctx, list.Slug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared.Owner == nil || shared.Owner.Username != "alice" || len(shared.Items) != 1 {
+		t.Errorf("public list = %+v", shared)
+	}
+
+	// 文章下线后公开列表中不再显示，所有者停用后列表不可访问
+	if err := store.Posts().UpdateStatus(ctx, post.ID, models.PostStatusPublished, models.PostStatusArchived, nil); err != nil {
+		t.Fatal(err)
+	}
+	shared, err = svc.GetPublic(ctx, list.Slug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shared.Items) != 0 {
+		t.Errorf("public items = %v, want none", itemTitles(shared.Items))
+	}
+	if err := store.Users().SetActive(ctx, alice.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.GetPublic(ctx, list.Slug)
+	expectError(t, err, apperror.ErrNotFound)
+}
+
+func itemTitles(items []models.ReadingListItem) []string {
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Post.Title
+	}
+	return titles
+}
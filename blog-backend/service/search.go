@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/search"
+)
+
+const searchSnippetLength = 160
+
+type SearchService struct {
+	posts repository.PostRepository
+	index search.Engine
+}
+
+func NewSearchService(posts repository.PostRepository, index search.Engine) *SearchService {
+	return &SearchService{posts: posts, index: index}
+}
+
+type SearchResult struct {
+	Post    models.Post `json:"post"`
+	Score   float64     `json:"score"`
+	Title   string      `json:"title_highlight"`
+	Snippet string      `json:"snippet"`
+}
+
+// Search 按相关度返回一页已发布文章，附带高亮后的标题和内容片段。
+func (s *SearchService) Search(ctx context.Context, q search.Query) ([]SearchResult, int64, error) {
+	hits, total, err := s.index.Search(q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.PostID)
+	}
+	posts, err := s.posts.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	terms := search.Tokenize(q.Text)
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		post, ok := byID[hit.PostID]
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{
+			Post:    post,
+			Score:   hit.Score,
+			Title:   search.Highlight(post.Title, terms, len([]rune(post.Title))),
+			Snippet: search.Highlight(post.Content, terms, searchSnippetLength),
+		})
+	}
+	return results, total, nil
+}
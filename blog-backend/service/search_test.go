@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+	"github.com/task/go_learn_task/blog-backend/search"
+)
+
+// fixedIndex 按给定顺序返回命中结果。
+type fixedIndex struct {
+	nopIndex
+	hits []search.Hit
+}
+
+func (f fixedIndex) Search(search.Query) ([]search.Hit, int64, error) {
+	return f.hits, int64(len(f.hits)), nil
+}
+
+func TestSearchServiceHighlight(t *testing.T) {
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	long := newPost(t, store, alice, "Go <Generics> 入门", models.PostStatusPublished)
+	long.Content = strings.Repeat("filler ", 40) + "then generics & more " + strings.Repeat("words ", 40)
+	if err := store.Posts().Update(context.Background(), long, nil); err != nil {
+		t.Fatal(err)
+	}
+	short := newPost(t, store, alice, "泛型", models.PostStatusPublished)
+	svc := NewSearchService(store.Posts(), fixedIndex{hits: []search.Hit{
+		{PostID: short.ID, Score: 2},
+		{PostID: 999, Score: 1.5},
+		{PostID: long.ID, Score: 1},
+	}})
+
+	results, _, err := svc.Search(context.Background(), search.Query{Text: "generics 泛", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 索引中已不存在的文章被跳过，其余保持命中顺序
+	if len(results) != 2 || results[0].Post.ID != short.ID || results[1].Post.ID != long.ID {
+		t.Fatalf("results = %+v", results)
+	}
+
+	if got := results[0].Title; got != "<mark>泛</mark>型" {
+		t.Errorf("han title = %q", got)
+	}
+	if got := results[0].Snippet; got != "content" {
+		t.Errorf("unmatched snippet = %q, want the full content", got)
+	}
+
+	// 标题完整保留并转义 HTML，正文截取命中词附近的片段
+	if got := results[1].Title; got != "Go &lt;<mark>Generics</mark>&gt; 入门" {
+		t.Errorf("title = %q", got)
+	}
+	snippet := results[1].Snippet
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Errorf("snippet %q is not trimmed on both sides", snippet)
+	}
+	if !strings.Contains(snippet, "then <mark>generics</mark> &amp; more") {
+		t.Errorf("snippet %q does not mark the match", snippet)
+	}
+	plain := strings.NewReplacer("<mark>", "", "</mark>", "", "&amp;", "&", "…", "").Replace(snippet)
+	if n := len([]rune(plain)); n != searchSnippetLength {
+		t.Errorf("snippet length = %d, want %d", n, searchSnippetLength)
+	}
+}
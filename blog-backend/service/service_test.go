@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+	"github.com/task/go_learn_task/blog-backend/search"
+)
+
+type nopEvents struct{}
+
+func (nopEvents) UserRegistered()     {}
+func (nopEvents) LoginAttempted(bool) {}
+func (nopEvents) PostCreated()        {}
+func (nopEvents) CommentCreated()     {}
+
+// nopIndex 忽略索引更新，服务测试不关心搜索结果。
+type nopIndex struct{}
+
+func (nopIndex) Search(search.Query) ([]search.Hit, int64, error) { return nil, 0, nil }
+func (nopIndex) IndexPost(*models.Post)                           {}
+func (nopIndex) RemovePost(uint)                                  {}
+func (nopIndex) IndexComment(*models.Comment)                     {}
+func (nopIndex) RemoveComment(uint)                               {}
+
+type nopViews struct{}
+
+func (nopViews) Record(uint, string) bool { return true }
+
+func newUser(t *testing.T, store *memory.Store, username string) *models.User {
+	t.Helper()
+	user := &models.User{Username: username, Email: username + "@example.com", Role: models.RoleAuthor, IsActive: true}
+	if err := store.Users().Create(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func newPost(t *testing.T, store *memory.Store, author *models.User, title, status string) *models.Post {
+	t.Helper()
+	post := &models.Post{Title: title, Content: "content", Status: status, UserID: author.ID}
+	if err := store.Posts().Create(context.Background(), post, nil); err != nil {
+		t.Fatal(err)
+	}
+	return post
+}
+
+// expectError 断言 err 是与 want 同一错误码的应用错误。
+func expectError(t *testing.T, err error, want *apperror.Error) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Errorf("err = %v, want %s", err, want.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+// trendingCommentWeight 是一条评论相当于多少次浏览。
+const trendingCommentWeight = 5
+
+// TrendingService 按近期的浏览和评论给已发布文章排序，越早的活动权重越低，每经过 halfLife 减半。
+type TrendingService struct {
+	posts    repository.PostRepository
+	views    repository.ViewRepository
+	halfLife time.Duration
+	now      func() time.Time
+}
+
+func NewTrendingService(posts repository.PostRepository, views repository.ViewRepository, halfLife time.Duration) *TrendingService {
+	return &TrendingService{posts: posts, views: views, halfLife: halfLife, now: time.Now}
+}
+
+type TrendingPost struct {
+	Post           models.Post `json:"post"`
+	Score          float64     `json:"score"`
+	RecentViews    int64       `json:"recent_views"`
+	RecentComments int64       `json:"recent_comments"`
+}
+
+// Trending 返回 window 内最热门的 limit 篇文章。浏览量按小时统计，尚未写入数据库的浏览不计入。
+func (s *TrendingService) Trending(ctx context.Context, window time.Duration, limit int) ([]TrendingPost, error) {
+	now := s.now()
+	trends, err := s.views.Trending(ctx, repository.TrendingQuery{
+		Since:         now.Add(-window),
+		Now:           now,
+		HalfLife:      s.halfLife,
+		CommentWeight: trendingCommentWeight,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(trends))
+	for i, trend := range trends {
+		ids[i] = trend.PostID
+	}
+	posts, err := s.posts.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	results := make([]TrendingPost, 0, len(trends))
+	for _, trend := range trends {
+		post, ok := byID[trend.PostID]
+		if !ok || !post.IsPublished() {
+			continue
+		}
+		results = append(results, TrendingPost{
+			Post:           post,
+			Score:          trend.Score,
+			RecentViews:    trend.Views,
+			RecentComments: trend.Comments,
+		})
+	}
+	return results, nil
+}
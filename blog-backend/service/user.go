@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+type UserService struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+}
+
+func NewUserService(users repository.UserRepository, sessions repository.SessionRepository) *UserService {
+	return &UserService{users: users, sessions: sessions}
+}
+
+func (s *UserService) List(ctx context.Context, role string) ([]models.User, error) {
+	return s.users.List(ctx, role)
+}
+
+func (s *UserService) UpdateRole(ctx context.Context, actor *models.User, id uint, role string) (*models.User, error) {
+	user, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == actor.ID && role != models.RoleAdmin {
+		return nil, apperror.BadRequest("You cannot demote yourself")
+	}
+
+	if err := s.users.UpdateRole(ctx, user.ID, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+	return user, nil
+}
+
+// SetActive 启用或停用用户，停用时吊销其所有会话。
+func (s *UserService) SetActive(ctx context.Context, actor *models.User, id uint, active bool) (*models.User, error) {
+	if id == actor.ID && !active {
+		return nil, apperror.BadRequest("You cannot deactivate yourself")
+	}
+	user, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.users.SetActive(ctx, user.ID, active); err != nil {
+		return nil, err
+	}
+	if !active {
+		if err := s.sessions.RevokeUser(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	user.IsActive = active
+	return user, nil
+}
+
+// Delete 软删除用户并吊销其所有会话。
+func (s *UserService) Delete(ctx context.Context, actor *models.User, id uint) error {
+	if id == actor.ID {
+		return apperror.BadRequest("You cannot delete yourself")
+	}
+	user, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.users.Delete(ctx, user.ID); err != nil {
+		return err
+	}
+	return s.sessions.RevokeUser(ctx, user.ID)
+}
+
+func (s *UserService) find(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.users.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, apperror.NotFound("User not found")
+	}
+	return user, err
+}
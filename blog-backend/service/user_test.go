@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/apperror"
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+)
+
+func TestUserServiceDeleteRevokesSessions(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	admin := newUser(t, store, "admin")
+	admin.Role = models.RoleAdmin
+	alice := newUser(t, store, "alice")
+	session := &models.Session{UserID: alice.ID, FamilyID: "family", RefreshTokenHash: "hash", AccessTokenID: "jti", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Sessions().Create(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewUserService(store.Users(), store.Sessions())
+
+	// 管理员不能降级或删除自己
+	_, err := svc.UpdateRole(ctx, admin, admin.ID, models.RoleAuthor)
+	expectError(t, err, apperror.ErrInvalidInput)
+	expectError(t, svc.Delete(ctx, admin, admin.ID), apperror.ErrInvalidInput)
+	expectError(t, svc.Delete(ctx, admin, 999), apperror.ErrNotFound)
+
+	if err := svc.Delete(ctx, admin, alice.ID); err != nil {
+		t.Fatal(err)
+	}
+	revoked, err := store.Sessions().FindByAccessTokenID(ctx, "jti")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked.IsActive() {
+		t.Error("session still active after deletion")
+	}
+	_, err = svc.UpdateRole(ctx, admin, alice.ID, models.RoleModerator)
+	expectError(t, err, apperror.ErrNotFound)
+
+	// 删除后释放用户名和邮箱
+	if exists, err := store.Users().ExistsByEmailOrUsername(ctx, alice.Email, alice.Username); err != nil || exists {
+		t.Errorf("deleted identity still taken: exists=%v err=%v", exists, err)
+	}
+}
+
+func TestUserServiceDeactivateRevokesSessions(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	admin := newUser(t, store, "admin")
+	admin.Role = models.RoleAdmin
+	alice := newUser(t, store, "alice")
+	session := &models.Session{UserID: alice.ID, FamilyID: "family", RefreshTokenHash: "hash", AccessTokenID: "jti", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Sessions().Create(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewUserService(store.Users(), store.Sessions())
+
+	_, err := svc.SetActive(ctx, admin, admin.ID, false)
+	expectError(t, err, apperror.ErrInvalidInput)
+	_, err = svc.SetActive(ctx, admin, 999, false)
+	expectError(t, err, apperror.ErrNotFound)
+
+	user, err := svc.SetActive(ctx, admin, alice.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.IsActive {
+		t.Error("user still active")
+	}
+	revoked, err := store.Sessions().FindByAccessTokenID(ctx, "jti")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked.IsActive() {
+		t.Error("session still active after deactivation")
+	}
+}
+
+func TestProfileServiceDeactivateChecksPassword(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	alice := newUser(t, store, "alice")
+	if err := alice.HashPassword("secret123"); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewProfileService(store.Users(), store.Sessions(), store.Posts())
+
+	expectError(t, svc.Deactivate(ctx, alice, "wrong"), &apperror.Error{Code: apperror.CodeValidationFailed})
+	if err := svc.Deactivate(ctx, alice, "secret123"); err != nil {
+		t.Fatal(err)
+	}
+	user, err := store.Users().FindByID(ctx, alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.IsActive {
+		t.Error("user still active after deactivation")
+	}
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
 	"github.com/task/go_learn_task/blog-backend/config"
@@ -13,19 +17,23 @@ type Claims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	Role     string `json:"role"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(user *models.User, cfg *config.Config) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
+// GenerateToken 签发短期访问令牌，tokenID 写入 jti，用于服务端吊销检查。
+func GenerateToken(user *models.User, tokenID string, cfg *config.Config) (string, error) {
+	now := time.Now()
 
 	claims := &Claims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Email:    user.Email,
+		Role:     user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(cfg.AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    "blog-backend",
 		},
 	}
@@ -39,7 +47,7 @@ func ValidateToken(tokenString string, cfg *config.Config) (*Claims, error) {
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		return []byte(cfg.JWTSecret), nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -51,3 +59,18 @@ func ValidateToken(tokenString string, cfg *config.Config) (*Claims, error) {
 
 	return claims, nil
 }
+
+// GenerateRandomToken 返回 n 字节随机数的 URL 安全编码，用作刷新令牌、jti 等不透明标识。
+func GenerateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken 返回令牌的 SHA-256 摘要，数据库中只保存摘要。
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 100
+
+	cursorSortField = "created_at"
+)
+
+var (
+	ErrInvalidPage   = errors.New("page must be a positive integer")
+	ErrInvalidLimit  = errors.New("limit must be a positive integer")
+	ErrInvalidSort   = errors.New("unsupported sort field")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Cursor 是游标分页的位置，按 (created_at, id) 定位，编码后对客户端不透明。
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
+	Desc      bool      `json:"d"`
+}
+
+func (cur Cursor) Encode() string {
+	b, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cur Cursor
+	if err := json.Unmarshal(b, &cur); err != nil || cur.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cur, nil
+}
+
+// PageParams 是从查询参数解析出的分页条件：page/limit 偏移分页，或 cursor 游标分页。
+type PageParams struct {
+	Page      int
+	Limit     int
+	SortField string
+	SortDesc  bool
+	Cursor    *Cursor
+}
+
+// Offset 返回偏移分页的起始位置，游标分页时为 0。
+func (p *PageParams) Offset() int {
+	if p.Cursor != nil {
+		return 0
+	}
+	return (p.Page - 1) * p.Limit
+}
+
+// ParsePageParams 解析 page、limit、sort 和 cursor 参数。limit 超过上限时截断为 MaxPageLimit；
+// sort 形如 created_at 或 -created_at，只能取 allowedSorts 中的字段，游标分页只支持 created_at。
+func ParsePageParams(c *gin.Context, allowedSorts ...string) (*PageParams, error) {
+	params := &PageParams{Page: 1, Limit: DefaultPageLimit, SortField: cursorSortField, SortDesc: true}
+
+	if v := c.Query("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return nil, ErrInvalidPage
+		}
+		params.Page = page
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return nil, ErrInvalidLimit
+		}
+		if limit > MaxPageLimit {
+			limit = MaxPageLimit
+		}
+		params.Limit = limit
+	}
+
+	if v := c.Query("sort"); v != "" {
+		params.SortDesc = strings.HasPrefix(v, "-")
+		params.SortField = strings.TrimPrefix(v, "-")
+		if params.SortField != cursorSortField && !contains(allowedSorts, params.SortField) {
+			return nil, ErrInvalidSort
+		}
+	}
+
+	if v := c.Query("cursor"); v != "" {
+		cur, err := DecodeCursor(v)
+		if err != nil {
+			return nil, err
+		}
+		if params.SortField != cursorSortField {
+			return nil, fmt.Errorf("%w: cursor paging only supports created_at", ErrInvalidSort)
+		}
+		// 游标中记录了排序方向，保证翻页过程中顺序一致
+		params.Cursor = cur
+		params.SortDesc = cur.Desc
+		params.Page = 0
+	}
+
+	return params, nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// PageResult 是列表接口统一的分页返回结构。
+type PageResult[T any] struct {
+	Items      []T    `json:"items"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Paginate 对 query 统计总数并取出一页数据。table 用于限定排序和游标条件中的列名，
+// key 返回记录的 (created_at, id)，用于生成下一页游标。query 只应包含过滤条件，
+// Preload 等只作用于取数据的设置通过 scopes 传入。
+func Paginate[T any](query *gorm.DB, params *PageParams, table string, key func(*T) (time.Time, uint), scopes ...func(*gorm.DB) *gorm.DB) (*PageResult[T], error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	dir := "ASC"
+	cmp := ">"
+	if params.SortDesc {
+		dir = "DESC"
+		cmp = "<"
+	}
+	column := func(name string) string { return table + "." + name }
+
+	page := query.Session(&gorm.Session{})
+	if params.Cursor != nil {
+		page = page.Where(
+			fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column("created_at"), cmp, column("created_at"), column("id"), cmp),
+			params.Cursor.CreatedAt, params.Cursor.CreatedAt, params.Cursor.ID,
+		)
+	}
+
+	items := make([]T, 0, params.Limit+1)
+	err := page.Scopes(scopes...).
+		Order(column(params.SortField) + " " + dir).
+		Order(column("id") + " " + dir).
+		Offset(params.Offset()).
+		Limit(params.Limit + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PageResult[T]{
+		Page:    params.Page,
+		Limit:   params.Limit,
+		Total:   total,
+		HasNext: len(items) > params.Limit,
+	}
+	if result.HasNext {
+		items = items[:params.Limit]
+		if params.SortField == cursorSortField {
+			createdAt, id := key(&items[len(items)-1])
+			result.NextCursor = Cursor{CreatedAt: createdAt, ID: id, Desc: params.SortDesc}.Encode()
+		}
+	}
+	result.Items = items
+
+	return result, nil
+}
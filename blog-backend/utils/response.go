@@ -1,16 +1,18 @@
 package utils
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 )
 
+// Response 是统一的响应结构。失败时 Code 为稳定的错误码，Details 为字段级的校验详情；
+// Error 与 Message 相同，为兼容旧客户端保留。
 type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
@@ -21,31 +23,13 @@ func SuccessResponse(c *gin.Context, statusCode int, message string, data interf
 	})
 }
 
-func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
-	errorMsg := ""
-	if err != nil {
-		errorMsg = err.Error()
-	}
-
+// ErrorResponse 写出错误响应，一般由 middleware.ErrorMiddleware 调用，处理器应通过 c.Error 上报错误。
+func ErrorResponse(c *gin.Context, statusCode int, code, message string, details interface{}) {
 	c.JSON(statusCode, Response{
 		Success: false,
 		Message: message,
-		Error:   errorMsg,
-	})
-}
-
-func ValidationErrorResponse(c *gin.Context, message string) {
-	c.JSON(http.StatusBadRequest, Response{
-		Success: false,
-		Message: message,
-		Error:   "Validation failed",
-	})
-}
-
-func UnauthorizedResponse(c *gin.Context) {
-	c.JSON(http.StatusUnauthorized, Response{
-		Success: false,
-		Message: "Unauthorized",
-		Error:   "Invalid or missing token",
+		Error:   message,
+		Code:    code,
+		Details: details,
 	})
 }
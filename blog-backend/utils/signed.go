@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSignedToken = errors.New("invalid signed token")
+	ErrSignedTokenExpired = errors.New("signed token expired")
+)
+
+// SignToken 生成带 HMAC-SHA256 签名的令牌，载荷包含用途、用户 ID、过期时间和随机数。
+// 签名只能证明令牌由服务端签发，一次性使用需要调用方另行记录。
+func SignToken(secret, purpose string, userID uint, expiresAt time.Time) (string, error) {
+	nonce, err := GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	payload := strings.Join([]string{purpose, strconv.FormatUint(uint64(userID), 10), strconv.FormatInt(expiresAt.Unix(), 10), nonce}, "|")
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + sign(secret, encoded), nil
+}
+
+// ParseSignedToken 校验签名、用途和有效期，返回令牌中的用户 ID。
+func ParseSignedToken(secret, purpose, token string, now time.Time) (uint, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(sign(secret, encoded))) {
+		return 0, ErrInvalidSignedToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, ErrInvalidSignedToken
+	}
+
+	parts := strings.Split(string(payload), "|")
+	if len(parts) != 4 || parts[0] != purpose {
+		return 0, ErrInvalidSignedToken
+	}
+	userID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSignedToken
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSignedToken
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return 0, ErrSignedTokenExpired
+	}
+	return uint(userID), nil
+}
+
+func sign(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
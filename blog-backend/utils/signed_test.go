@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignedToken(t *testing.T) {
+	now := time.Now()
+	token, err := SignToken("secret", "reset_password", 42, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id, err := ParseSignedToken("secret", "reset_password", token, now); err != nil || id != 42 {
+		t.Fatalf("ParseSignedToken = %d, %v; want 42", id, err)
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		purpose string
+		token   string
+		now     time.Time
+		want    error
+	}{
+		{"wrong secret", "other", "reset_password", token, now, ErrInvalidSignedToken},
+		{"wrong purpose", "secret", "verify_email", token, now, ErrInvalidSignedToken},
+		{"tampered", "secret", "reset_password", "x" + token, now, ErrInvalidSignedToken},
+		{"malformed", "secret", "reset_password", "not-a-token", now, ErrInvalidSignedToken},
+		{"expired", "secret", "reset_password", token, now.Add(time.Hour), ErrSignedTokenExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSignedToken(tt.secret, tt.purpose, tt.token, tt.now); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
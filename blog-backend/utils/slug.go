@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify 将名称转换为 URL 友好的标识：小写字母、数字，其余字符合并为单个连字符。
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,138 @@
+// Package views 统计文章浏览量。同一访客在去重窗口内重复浏览同一篇文章只计一次，
+// 增量先在内存中按文章和小时累加，由后台任务批量写入数据库，避免热门文章频繁更新同一行造成锁竞争。
+// 去重记录和未写入的增量保存在进程内存中，只适用于单实例部署，进程异常退出时会丢失最近一批增量。
+package views
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/repository"
+)
+
+// MaxWindow 是按小时浏览统计的保留时长，也是热门文章统计窗口的上限。
+const MaxWindow = 7 * 24 * time.Hour
+
+// pruneEvery 控制清理过期浏览统计的频率。
+const pruneEvery = time.Hour
+
+type seenKey struct {
+	postID uint
+	viewer string
+}
+
+type bucketKey struct {
+	postID uint
+	bucket time.Time
+}
+
+// Tracker 记录浏览并定期写入数据库。
+type Tracker struct {
+	store  repository.ViewRepository
+	window time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	// seen 记录每个访客的去重截止时间
+	seen      map[seenKey]time.Time
+	pending   map[bucketKey]int64
+	lastPrune time.Time
+}
+
+// NewTracker 创建 Tracker，window 为同一访客重复浏览不计数的时长。
+func NewTracker(store repository.ViewRepository, window time.Duration) *Tracker {
+	return &Tracker{
+		store:   store,
+		window:  window,
+		now:     time.Now,
+		seen:    make(map[seenKey]time.Time),
+		pending: make(map[bucketKey]int64),
+	}
+}
+
+// Record 记录一次浏览，viewer 标识访客，例如用户 ID 或 IP。去重窗口内的重复浏览返回 false。
+func (t *Tracker) Record(postID uint, viewer string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	key := seenKey{postID, viewer}
+	if until, ok := t.seen[key]; ok && now.Before(until) {
+		return false
+	}
+	t.seen[key] = now.Add(t.window)
+	t.pending[bucketKey{postID, now.Truncate(time.Hour)}]++
+	return true
+}
+
+// Flush 把缓存的增量写入数据库，返回写入的浏览量。写入失败时增量放回缓存，下次重试。
+func (t *Tracker) Flush(ctx context.Context) (int64, error) {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[bucketKey]int64)
+	now := t.now()
+	for key, until := range t.seen {
+		if !now.Before(until) {
+			delete(t.seen, key)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	deltas := make([]repository.ViewDelta, 0, len(pending))
+	var total int64
+	for key, n := range pending {
+		deltas = append(deltas, repository.ViewDelta{PostID: key.postID, Bucket: key.bucket, Views: n})
+		total += n
+	}
+
+	if err := t.store.AddViews(ctx, deltas); err != nil {
+		t.mu.Lock()
+		for key, n := range pending {
+			t.pending[key] += n
+		}
+		t.mu.Unlock()
+		return 0, err
+	}
+	return total, nil
+}
+
+// Run 每隔 interval 写入一次增量并定期清理过期统计，ctx 取消后写入剩余增量再退出。
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// 退出前写入剩余增量，调用方需在关闭数据库之前等待 Run 返回
+			if _, err := t.Flush(context.WithoutCancel(ctx)); err != nil {
+				slog.Error("failed to flush post views on shutdown", "error", err)
+			}
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := t.Flush(ctx); err != nil {
+			slog.Error("failed to flush post views", "error", err)
+		}
+		t.prune(ctx)
+	}
+}
+
+func (t *Tracker) prune(ctx context.Context) {
+	now := t.now()
+	if now.Sub(t.lastPrune) < pruneEvery {
+		return
+	}
+	t.lastPrune = now
+	if n, err := t.store.PruneStats(ctx, now.Add(-MaxWindow)); err != nil {
+		slog.Error("failed to prune post view stats", "error", err)
+	} else if n > 0 {
+		slog.Info("pruned post view stats", "count", n)
+	}
+}
@@ -0,0 +1,102 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/task/go_learn_task/blog-backend/models"
+	"github.com/task/go_learn_task/blog-backend/repository"
+	"github.com/task/go_learn_task/blog-backend/repository/memory"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// failingStore 模拟数据库不可用，写入总是失败。
+type failingStore struct {
+	repository.ViewRepository
+}
+
+func (failingStore) AddViews(context.Context, []repository.ViewDelta) error {
+	return errors.New("database unavailable")
+}
+
+func TestTrackerDeduplicatesAndFlushes(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	post := &models.Post{Title: "t", Content: "c", Status: models.PostStatusPublished, UserID: 1}
+	if err := store.Posts().Create(ctx, post, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 10, 50, 0, 0, time.UTC)}
+	tracker := NewTracker(store.Views(), 30*time.Minute)
+	tracker.now = clock.Now
+
+	if !tracker.Record(post.ID, "user:1") {
+		t.Fatal("first view not counted")
+	}
+	if tracker.Record(post.ID, "user:1") {
+		t.Fatal("repeat view within the window counted")
+	}
+	tracker.Record(post.ID, "ip:192.0.2.1")
+
+	// 去重窗口过后再次计数，并落入下一个小时
+	clock.Advance(30 * time.Minute)
+	if !tracker.Record(post.ID, "user:1") {
+		t.Fatal("view after the window not counted")
+	}
+
+	n, err := tracker.Flush(ctx)
+	if err != nil || n != 3 {
+		t.Fatalf("Flush() = %d, %v; want 3", n, err)
+	}
+	if n, _ := tracker.Flush(ctx); n != 0 {
+		t.Fatalf("second Flush() = %d, want 0", n)
+	}
+
+	got, _ := store.Posts().FindByID(ctx, post.ID, false)
+	if got.ViewCount != 3 {
+		t.Errorf("view_count = %d, want 3", got.ViewCount)
+	}
+	// 10 点的两次浏览距 11 点一个半衰期，权重减半
+	trends, err := store.Views().Trending(ctx, repository.TrendingQuery{
+		Now:      time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		HalfLife: time.Hour,
+		Limit:    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trends) != 1 || trends[0].Views != 3 || trends[0].Score != 2 {
+		t.Errorf("trends = %+v, want 3 views scored 2", trends)
+	}
+}
+
+func TestTrackerKeepsViewsWhenFlushFails(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	post := &models.Post{Title: "t", Content: "c", Status: models.PostStatusPublished, UserID: 1}
+	if err := store.Posts().Create(ctx, post, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewTracker(failingStore{store.Views()}, time.Minute)
+	tracker.Record(post.ID, "user:1")
+	tracker.Record(post.ID, "user:2")
+	if _, err := tracker.Flush(ctx); err == nil {
+		t.Fatal("Flush() succeeded with a failing store")
+	}
+
+	tracker.store = store.Views()
+	if n, err := tracker.Flush(ctx); err != nil || n != 2 {
+		t.Fatalf("retry Flush() = %d, %v; want 2", n, err)
+	}
+}